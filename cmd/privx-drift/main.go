@@ -0,0 +1,110 @@
+// Command privx-drift reports PrivX objects that are not managed by
+// Terraform, objects in state that no longer exist in PrivX, and objects
+// that were modified outside Terraform.
+//
+// It reads state files or terraform show -json output ("-" for stdin) and
+// connects with the same PRIVX_* environment variables as the provider.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/drift"
+)
+
+func main() {
+	var out, format, types string
+	flag.StringVar(&out, "out", "", "file to write the report to (default stdout)")
+	flag.StringVar(&format, "format", "json", "report format, json or markdown")
+	flag.StringVar(&types, "types", strings.Join(drift.Types, ","), "comma separated resource types to check")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] STATE_FILE...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if format != "json" && format != "markdown" {
+		fmt.Fprintf(os.Stderr, "privx-drift: unsupported format %q\n", format)
+		os.Exit(2)
+	}
+
+	if err := run(out, format, strings.Split(types, ","), flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "privx-drift:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, format string, types, stateFiles []string) error {
+	var managed []drift.Managed
+	for _, name := range stateFiles {
+		found, err := readState(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		managed = append(managed, found...)
+	}
+
+	// The connector logs its credentials; keep them out of the output.
+	log.SetOutput(io.Discard)
+
+	config := client.ConfigFromEnv()
+	if config.APIBaseURL == "" {
+		return fmt.Errorf("PRIVX_API_BASE_URL is not set")
+	}
+	connector, err := client.NewConnector(
+		config.APIBaseURL,
+		config.BearerToken,
+		config.APIClientID,
+		config.APIClientSecret,
+		config.OAuthClientID,
+		config.OAuthClientSecret,
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := range types {
+		types[i] = strings.TrimSpace(types[i])
+	}
+	remote, err := drift.ListObjects(*connector, types)
+	if err != nil {
+		return err
+	}
+	report := drift.Compare(managed, remote, types)
+
+	w := io.Writer(os.Stdout)
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "markdown" {
+		return report.WriteMarkdown(w)
+	}
+	return report.WriteJSON(w)
+}
+
+func readState(name string) ([]drift.Managed, error) {
+	if name == "-" {
+		return drift.ReadState(os.Stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return drift.ReadState(f)
+}
@@ -0,0 +1,74 @@
+// Command privx-export writes Terraform configuration with import blocks
+// for the objects of a live PrivX deployment.
+//
+// It connects with the same PRIVX_* environment variables as the provider.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"terraform-provider-privx/internal/client"
+	"terraform-provider-privx/internal/export"
+)
+
+func main() {
+	var out, types string
+	flag.StringVar(&out, "out", "", "file to write the configuration to (default stdout)")
+	flag.StringVar(&types, "types", strings.Join(export.Types, ","), "comma separated resource types to export")
+	flag.Parse()
+
+	if err := run(out, strings.Split(types, ",")); err != nil {
+		fmt.Fprintln(os.Stderr, "privx-export:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string, types []string) error {
+	// The connector logs its credentials; keep them out of the output.
+	log.SetOutput(io.Discard)
+
+	config := client.ConfigFromEnv()
+	if config.APIBaseURL == "" {
+		return fmt.Errorf("PRIVX_API_BASE_URL is not set")
+	}
+	connector, err := client.NewConnector(
+		config.APIBaseURL,
+		config.BearerToken,
+		config.APIClientID,
+		config.APIClientSecret,
+		config.OAuthClientID,
+		config.OAuthClientSecret,
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := range types {
+		types[i] = strings.TrimSpace(types[i])
+	}
+	objects, err := export.NewLister(*connector).List(types)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := export.WriteHCL(w, objects); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "privx-export: exported %d objects\n", len(objects))
+	return nil
+}
@@ -0,0 +1,5 @@
+data "privx_access_groups" "all" {}
+
+output "access_group_ids_by_name" {
+  value = { for ag in data.privx_access_groups.all.access_groups : ag.name => ag.id }
+}
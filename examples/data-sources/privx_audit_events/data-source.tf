@@ -0,0 +1,13 @@
+# Compliance check: nobody connected to the host after it was decommissioned.
+data "privx_audit_events" "after_decommission" {
+  host_id     = var.decommissioned_host_id
+  start_time  = var.decommissioned_at
+  event_types = ["SSH_CONNECTION_ESTABLISHED", "RDP_CONNECTION_ESTABLISHED"]
+}
+
+check "no_logins_after_decommission" {
+  assert {
+    condition     = length(data.privx_audit_events.after_decommission.events) == 0
+    error_message = "Connections to the decommissioned host were recorded."
+  }
+}
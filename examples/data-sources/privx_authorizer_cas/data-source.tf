@@ -0,0 +1,13 @@
+# User CA keys of one access group, for sshd TrustedUserCAKeys
+data "privx_authorizer_cas" "default" {
+  access_group_id = privx_access_group.example.id
+}
+
+resource "local_file" "trusted_user_ca_keys" {
+  content  = data.privx_authorizer_cas.default.trusted_user_ca_keys
+  filename = "trusted_user_ca_keys.pub"
+}
+
+output "privx_tls_trust_anchor" {
+  value = data.privx_authorizer_cas.default.tls_trust_anchor
+}
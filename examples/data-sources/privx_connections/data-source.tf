@@ -0,0 +1,10 @@
+# Live SSH sessions to a host
+data "privx_connections" "live" {
+  host_id  = privx_host.example.id
+  protocol = "SSH"
+  status   = "CONNECTED"
+}
+
+output "live_session_users" {
+  value = [for c in data.privx_connections.live.connections : c.user_display_name]
+}
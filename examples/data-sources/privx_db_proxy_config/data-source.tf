@@ -0,0 +1,7 @@
+data "privx_db_proxy_config" "this" {}
+
+# CA bundle for psql sslrootcert / mysql --ssl-ca
+resource "local_file" "db_proxy_ca" {
+  content  = data.privx_db_proxy_config.this.ca_certificate_chain
+  filename = "privx-db-proxy-ca.pem"
+}
@@ -0,0 +1,26 @@
+data "privx_host_deploy_script" "example" {
+  trusted_client_id = "12345678-1234-1234-1234-123456789012"
+}
+
+data "privx_authorizer_cas" "default" {}
+
+# Register the host with PrivX and trust the PrivX CAs on first boot
+locals {
+  user_data = <<-EOT
+    #cloud-config
+    write_files:
+      - path: /opt/privx/deploy.sh
+        permissions: "0700"
+        encoding: b64
+        content: ${data.privx_host_deploy_script.example.script_base64}
+      - path: /etc/ssh/privx_ca.pub
+        content: |
+          ${indent(6, data.privx_authorizer_cas.default.trusted_user_ca_keys)}
+      - path: /etc/ssh/principals_command.sh
+        permissions: "0755"
+        encoding: b64
+        content: ${base64encode(data.privx_host_deploy_script.example.principals_command_script)}
+    runcmd:
+      - /opt/privx/deploy.sh
+  EOT
+}
@@ -0,0 +1,16 @@
+# Production Linux hosts in one AWS region
+data "privx_hosts" "prod" {
+  tags                  = ["env:prod"]
+  cloud_provider        = "AWS"
+  cloud_provider_region = "eu-west-1"
+  service               = "SSH"
+}
+
+# Hosts with an address in the database subnet
+data "privx_hosts" "db_subnet" {
+  address_cidr = "10.20.0.0/16"
+}
+
+output "prod_host_names" {
+  value = [for h in data.privx_hosts.prod.hosts : h.common_name]
+}
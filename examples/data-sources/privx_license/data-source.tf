@@ -1 +1,9 @@
-data "privx_licence" "my_license" {}
+data "privx_license" "my_license" {}
+
+output "license_expires" {
+  value = data.privx_license.my_license.expires
+}
+
+output "license_user_seats" {
+  value = "${data.privx_license.my_license.current_users}/${data.privx_license.my_license.max_users}"
+}
@@ -0,0 +1,7 @@
+data "privx_local_user" "alice" {
+  username = "alice"
+}
+
+output "alice_roles" {
+  value = data.privx_local_user.alice.role_names
+}
@@ -0,0 +1,8 @@
+data "privx_managed_account" "svc_backup" {
+  target_domain_id = "9d0e1f2a-3b4c-5d6e-7f80-91a2b3c4d5e6"
+  username         = "svc_backup"
+}
+
+output "svc_backup_last_rotated" {
+  value = data.privx_managed_account.svc_backup.last_rotated
+}
@@ -0,0 +1,8 @@
+data "privx_role_principal_keys" "admins" {
+  role_id = privx_role.admins.id
+}
+
+# authorized_keys content for the target account
+output "admins_authorized_keys" {
+  value = data.privx_role_principal_keys.admins.authorized_keys
+}
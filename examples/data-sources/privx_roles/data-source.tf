@@ -0,0 +1,9 @@
+# All roles of the production team
+data "privx_roles" "prod" {
+  name_regex = "^prod-"
+  tags       = ["team:platform"]
+}
+
+output "prod_role_names" {
+  value = [for r in data.privx_roles.prod.roles : r.name]
+}
@@ -0,0 +1,9 @@
+# Secret metadata only; values are never read
+data "privx_secrets" "db" {
+  name_regex   = "^db-"
+  read_role_id = privx_role.dba.id
+}
+
+output "db_secret_names" {
+  value = data.privx_secrets.db.names
+}
@@ -0,0 +1,8 @@
+data "privx_settings" "rolestore" {
+  scope   = "rolestore"
+  section = "mfa"
+}
+
+output "mfa_settings" {
+  value = data.privx_settings.rolestore.settings
+}
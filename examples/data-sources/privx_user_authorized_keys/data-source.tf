@@ -0,0 +1,7 @@
+data "privx_user_authorized_keys" "alice" {
+  user_id = privx_local_user.alice.id
+}
+
+output "alice_key_fingerprints" {
+  value = [for k in data.privx_user_authorized_keys.alice.keys : k.fingerprint_sha256]
+}
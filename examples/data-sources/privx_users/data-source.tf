@@ -0,0 +1,8 @@
+# Users holding the admin role
+data "privx_users" "admins" {
+  role_id = privx_role.admins.id
+}
+
+output "admin_principals" {
+  value = [for u in data.privx_users.admins.users : u.principal]
+}
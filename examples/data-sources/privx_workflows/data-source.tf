@@ -0,0 +1,8 @@
+# Workflows that can grant the admin role
+data "privx_workflows" "admin" {
+  target_role_id = privx_role.admins.id
+}
+
+output "admin_workflow_names" {
+  value = [for wf in data.privx_workflows.admin.workflows : wf.name]
+}
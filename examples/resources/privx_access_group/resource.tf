@@ -2,3 +2,16 @@ resource "privx_access_group" "foo" {
   name    = "NFS2"
   comment = "Hosts using NFS home directories"
 }
+
+resource "privx_access_group" "ops" {
+  name    = "ops"
+  comment = "Operations hosts"
+
+  # Change to rotate the access group CA key.
+  ca_rotate_trigger = "2025-01"
+}
+
+# Trust anchor for hosts accepting certificates signed by the group CA.
+output "ops_ca_public_key" {
+  value = privx_access_group.ops.ca_public_key
+}
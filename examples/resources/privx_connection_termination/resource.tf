@@ -0,0 +1,8 @@
+# Incident response: kill every live session of a compromised user.
+resource "privx_connection_termination" "incident" {
+  user_id = data.privx_local_user.compromised.id
+
+  triggers = {
+    incident = "INC-1234"
+  }
+}
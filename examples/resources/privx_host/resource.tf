@@ -38,6 +38,9 @@ resource "privx_host" "test" {
   external_id = "tf-ext-demo"
   comment     = "created by terraform"
 
+  # Kill live sessions to the host before it is deleted.
+  terminate_connections_on_destroy = true
+
   services = [{
     service                   = "SSH"
     address                   = var.host_address
@@ -65,3 +68,26 @@ resource "privx_host" "test" {
     passphrase                = var.initial_passphrase
   }]
 }
+
+# PostgreSQL target reached through the PrivX DB proxy
+resource "privx_host" "postgres" {
+  common_name     = "tf-example-postgres"
+  addresses       = ["10.0.0.20"]
+  access_group_id = data.privx_access_group.ag.id
+
+  services = [{
+    service = "DB"
+    address = "10.0.0.20"
+    port    = 5432
+    db = {
+      protocol                      = "postgres"
+      tls_certificate_validation    = "ENABLED"
+      tls_certificate_trust_anchors = file("${path.module}/postgres-ca.pem")
+    }
+  }]
+
+  principals = [{
+    principal  = "app_readonly"
+    passphrase = var.initial_passphrase
+  }]
+}
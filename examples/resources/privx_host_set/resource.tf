@@ -0,0 +1,56 @@
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {
+  # Cap the requests in flight while registering large inventories
+  max_concurrent_requests = 8
+}
+
+data "privx_access_group" "ag" {
+  name = "Default"
+}
+
+data "privx_role" "admins" {
+  name = "privx-admin"
+}
+
+variable "inventory" {
+  description = "Hosts keyed by hostname"
+  type = map(object({
+    address = string
+    tags    = list(string)
+  }))
+  default = {
+    "web-01" = { address = "10.0.1.11", tags = ["web", "prod"] }
+    "web-02" = { address = "10.0.1.12", tags = ["web", "prod"] }
+    "db-01"  = { address = "10.0.2.11", tags = ["db", "prod"] }
+  }
+}
+
+resource "privx_host_set" "inventory" {
+  name            = "prod-inventory"
+  access_group_id = data.privx_access_group.ag.id
+  parallelism     = 20
+
+  hosts = {
+    for name, host in var.inventory : name => {
+      common_name = name
+      addresses   = [host.address]
+      services    = [{ service = "SSH", port = 22 }]
+      principals = [{
+        principal = "admin"
+        role_ids  = [data.privx_role.admins.id]
+      }]
+      tags = host.tags
+    }
+  }
+}
+
+output "host_ids" {
+  value = privx_host_set.inventory.host_ids
+}
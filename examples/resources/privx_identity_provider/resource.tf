@@ -0,0 +1,26 @@
+resource "privx_identity_provider" "corp_sso" {
+  name         = "corp-sso"
+  jwt_issuer   = "https://login.example.com/"
+  jwt_audience = "privx"
+
+  jwt_subject_type = "username"
+
+  custom_attributes = [
+    {
+      field_name     = "groups"
+      type           = "string"
+      expected_value = "privx-users"
+    },
+  ]
+
+  public_key_method = "static"
+  public_keys = [
+    {
+      key_id     = "2024-01"
+      public_key = file("${path.module}/idp-signing-key.pem")
+    },
+  ]
+
+  users_directory = privx_source.corp.id
+  enabled         = true
+}
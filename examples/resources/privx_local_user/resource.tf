@@ -11,6 +11,14 @@ resource "privx_local_user" "example" {
 
   password                  = var.initial_password
   password_change_required  = true
+  password_expires          = "2030-01-01T00:00:00Z"
   tags                      = ["team-a", "oncall"]
-}
 
+  role_names = ["privx-user"]
+
+  # Set to true when offboarding: revokes all explicit role grants.
+  disabled = false
+
+  # Change to reset the user's MFA enrolment.
+  mfa_reset_trigger = "1"
+}
@@ -0,0 +1,13 @@
+resource "privx_managed_account" "svc_backup" {
+  target_domain_id = "9d0e1f2a-3b4c-5d6e-7f80-91a2b3c4d5e6"
+  username         = "svc_backup"
+  comment          = "Backup service account"
+  rotation_enabled = true
+
+  # Change this value to rotate the password on the next apply
+  rotate_trigger = "2026-10-01"
+}
+
+output "svc_backup_rotation_failures" {
+  value = privx_managed_account.svc_backup.rotation_failures
+}
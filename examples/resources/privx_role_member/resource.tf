@@ -0,0 +1,14 @@
+# Permanent explicit grant
+resource "privx_role_member" "alice_admins" {
+  user_id = privx_local_user.alice.id
+  role_id = privx_role.admins.id
+}
+
+# Grant valid for a maintenance window only
+resource "privx_role_member" "bob_maintenance" {
+  user_id     = privx_local_user.bob.id
+  role_id     = privx_role.maintenance.id
+  grant_type  = "TIME_RESTRICTED"
+  grant_start = "2026-01-10T08:00:00Z"
+  grant_end   = "2026-01-10T16:00:00Z"
+}
@@ -0,0 +1,15 @@
+# Authoritative: any other explicit grant of the role is revoked.
+resource "privx_role_members" "oncall" {
+  role_id = privx_role.oncall.id
+
+  members = [
+    {
+      user_id = privx_local_user.alice.id
+    },
+    {
+      user_id         = privx_local_user.bob.id
+      grant_type      = "FLOATING"
+      floating_length = 8
+    },
+  ]
+}
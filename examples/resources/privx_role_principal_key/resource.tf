@@ -0,0 +1,19 @@
+# Key generated by PrivX, rotated by changing rotate_trigger
+resource "privx_role_principal_key" "generated" {
+  role_id        = privx_role.admins.id
+  rotate_trigger = "2026-01"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# Imported Ed25519 key
+resource "privx_role_principal_key" "imported" {
+  role_id     = privx_role.admins.id
+  private_key = file("${path.module}/keys/admins_ed25519")
+}
+
+output "admins_principal_key_fingerprint" {
+  value = privx_role_principal_key.generated.fingerprint_sha256
+}
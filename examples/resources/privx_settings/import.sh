@@ -0,0 +1,2 @@
+# Import the listed keys of a scope with their current values.
+terraform import privx_settings.connection_manager "connection-manager:session.idle_timeout,session.max_session_time"
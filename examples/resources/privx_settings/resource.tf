@@ -0,0 +1,10 @@
+# Only the listed keys are managed; other settings in the scope are left untouched.
+resource "privx_settings" "connection_manager" {
+  scope = "connection-manager"
+
+  settings = {
+    "session.idle_timeout"       = "1800"
+    "session.max_session_time"   = "28800"
+    "trail.enable_file_auditing" = "true"
+  }
+}
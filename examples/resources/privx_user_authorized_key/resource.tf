@@ -0,0 +1,9 @@
+resource "privx_user_authorized_key" "alice_laptop" {
+  user_id    = privx_local_user.alice.id
+  name       = "alice-laptop"
+  public_key = file("${path.module}/keys/alice_laptop.pub")
+  comment    = "Provisioned during onboarding"
+  source     = "onboarding"
+
+  source_address = ["10.0.0.0/8"]
+}
@@ -0,0 +1,14 @@
+resource "privx_webproxy" "example" {
+  name              = "mywebproxy"
+  access_group_id   = "33304b0b-e62e-44ef-b78f-26bb5e0edc88"
+  web_proxy_address = "10.0.0.10"
+  web_proxy_port    = 8080
+  subnets = [
+    "10.0.0.0/16"
+  ]
+  web_proxy_extender_route_patterns = ["*.internal.example.com"]
+}
+
+data "privx_webproxy_config" "example" {
+  id = privx_webproxy.example.id
+}
@@ -0,0 +1,20 @@
+# Break-glass access: request the role for one hour and wait for approval.
+resource "privx_workflow_request" "break_glass" {
+  role_id       = data.privx_role.emergency_admin.id
+  justification = "Incident INC-1234"
+
+  grant_type  = "TIME_RESTRICTED"
+  grant_start = timestamp()
+  grant_end   = timeadd(timestamp(), "1h")
+
+  wait_for_decision = true
+  decision_timeout  = "15m"
+
+  lifecycle {
+    ignore_changes = [grant_start, grant_end]
+  }
+}
+
+output "break_glass_status" {
+  value = privx_workflow_request.break_glass.status
+}
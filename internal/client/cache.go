@@ -0,0 +1,228 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+// Cache memoizes PrivX API reads for the lifetime of the provider process,
+// which Terraform starts once per plan or apply. Data sources read through
+// it; resources bypass it and drop all entries after every write.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	ready  chan struct{}
+	body   json.RawMessage
+	header http.Header
+	err    error
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{entries: map[string]*cacheEntry{}}
+}
+
+// Reader wraps a connector so that GET requests and search or resolve
+// queries are served from the cache. Concurrent identical requests share
+// one API call. Other POST requests, such as the download sessions some
+// data sources open, go to the API without invalidating the cache; PUT and
+// DELETE requests invalidate it.
+func (c *Cache) Reader(connector restapi.Connector) *restapi.Connector {
+	var wrapped restapi.Connector = &cachedConnector{inner: connector, cache: c, read: true}
+	return &wrapped
+}
+
+// Writer wraps a connector so that every request goes to the API and every
+// write invalidates the cache.
+func (c *Cache) Writer(connector restapi.Connector) *restapi.Connector {
+	var wrapped restapi.Connector = &cachedConnector{inner: connector, cache: c}
+	return &wrapped
+}
+
+// Invalidate drops all cached responses.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]*cacheEntry{}
+}
+
+// get returns the cached response for key, calling fetch on a miss. Failed
+// fetches are not cached.
+func (c *Cache) get(key string, fetch func() (json.RawMessage, http.Header, error)) (json.RawMessage, http.Header, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-entry.ready
+		return entry.body, entry.header, entry.err
+	}
+	entry := &cacheEntry{ready: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.body, entry.header, entry.err = fetch()
+	close(entry.ready)
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return entry.body, entry.header, entry.err
+}
+
+type cachedConnector struct {
+	inner restapi.Connector
+	cache *Cache
+	read  bool
+}
+
+func (c *cachedConnector) URL(templatePath string, args ...interface{}) restapi.CURL {
+	escapedArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		if str, ok := arg.(string); ok {
+			escapedArgs[i] = url.PathEscape(str)
+		} else {
+			escapedArgs[i] = arg
+		}
+	}
+	path := fmt.Sprintf(templatePath, escapedArgs...)
+
+	return &cachedCURL{
+		inner:     c.inner.URL(templatePath, args...),
+		cache:     c.cache,
+		path:      path,
+		key:       path,
+		read:      c.read,
+		cacheable: c.read,
+	}
+}
+
+type cachedCURL struct {
+	inner     restapi.CURL
+	cache     *Cache
+	path      string
+	key       string
+	read      bool
+	cacheable bool
+}
+
+func (curl *cachedCURL) Query(data interface{}) restapi.CURL {
+	curl.inner = curl.inner.Query(data)
+	if values, ok := data.(url.Values); ok {
+		curl.key += "?" + values.Encode()
+	} else if bin, err := json.Marshal(data); err == nil {
+		curl.key += "?" + string(bin)
+	} else {
+		curl.cacheable = false
+	}
+	return curl
+}
+
+// Requests with custom headers or cookies are never cached.
+func (curl *cachedCURL) Header(head, value string) restapi.CURL {
+	curl.inner = curl.inner.Header(head, value)
+	curl.cacheable = false
+	return curl
+}
+
+func (curl *cachedCURL) CookieJar(jar http.CookieJar) restapi.CURL {
+	curl.inner = curl.inner.CookieJar(jar)
+	curl.cacheable = false
+	return curl
+}
+
+func (curl *cachedCURL) Status(status ...int) (http.Header, error) {
+	return curl.inner.Status(status...)
+}
+
+func (curl *cachedCURL) Get(in interface{}) (http.Header, error) {
+	if !curl.cacheable {
+		return curl.inner.Get(in)
+	}
+
+	body, header, err := curl.cache.get("GET "+curl.key, func() (json.RawMessage, http.Header, error) {
+		var raw json.RawMessage
+		header, err := curl.inner.Get(&raw)
+		return raw, header, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, in); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (curl *cachedCURL) Put(eg interface{}, in ...interface{}) (http.Header, error) {
+	defer curl.cache.Invalidate()
+	return curl.inner.Put(eg, in...)
+}
+
+// Post treats search and resolve endpoints as reads. Any other POST made by
+// a resource is a write and invalidates the cache; data sources do not
+// change the objects they read, so their POSTs are sent uncached.
+func (curl *cachedCURL) Post(eg interface{}, in ...interface{}) (http.Header, error) {
+	if !isQueryPath(curl.path) {
+		if !curl.read {
+			defer curl.cache.Invalidate()
+		}
+		return curl.inner.Post(eg, in...)
+	}
+
+	payload, err := json.Marshal(eg)
+	if !curl.cacheable || len(in) == 0 || err != nil {
+		return curl.inner.Post(eg, in...)
+	}
+
+	body, header, err := curl.cache.get("POST "+curl.key+" "+string(payload), func() (json.RawMessage, http.Header, error) {
+		var raw json.RawMessage
+		header, err := curl.inner.Post(eg, &raw)
+		return raw, header, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, in[0]); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (curl *cachedCURL) Delete(in ...interface{}) (http.Header, error) {
+	defer curl.cache.Invalidate()
+	return curl.inner.Delete(in...)
+}
+
+func (curl *cachedCURL) Fetch() ([]byte, error) {
+	return curl.inner.Fetch()
+}
+
+func (curl *cachedCURL) Download(filename string) error {
+	return curl.inner.Download(filename)
+}
+
+// isQueryPath reports whether a POST to path only reads data.
+func isQueryPath(path string) bool {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "search" || segment == "resolve" {
+			return true
+		}
+	}
+	return false
+}
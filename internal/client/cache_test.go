@@ -0,0 +1,222 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+type testHost struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCacheSingleFlight(t *testing.T) {
+	fake := &fakeConnector{
+		delay: 50 * time.Millisecond,
+		respond: func(method, path string) (interface{}, error) {
+			return testHost{ID: "h1", Name: "web"}, nil
+		},
+	}
+	reader := *NewCache().Reader(fake)
+
+	const readers = 10
+	var wg sync.WaitGroup
+	results := make([]testHost, readers)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := reader.URL("/host-store/api/v1/hosts/%s", "h1").Get(&results[i]); err != nil {
+				t.Errorf("Get: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := fake.count(http.MethodGet, "/host-store/api/v1/hosts/h1"); n != 1 {
+		t.Errorf("%d concurrent reads made %d API requests, want 1", readers, n)
+	}
+	for i, r := range results {
+		if r.Name != "web" {
+			t.Errorf("reader %d got %+v", i, r)
+		}
+	}
+}
+
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	fail := true
+	fake := &fakeConnector{
+		respond: func(method, path string) (interface{}, error) {
+			if fail {
+				return nil, errors.New("HTTP error: 500 Internal Server Error")
+			}
+			return testHost{ID: "h1"}, nil
+		},
+	}
+	reader := *NewCache().Reader(fake)
+
+	var host testHost
+	if _, err := reader.URL("/host-store/api/v1/hosts/h1").Get(&host); err == nil {
+		t.Fatal("first Get succeeded, want the API error")
+	}
+
+	fail = false
+	if _, err := reader.URL("/host-store/api/v1/hosts/h1").Get(&host); err != nil {
+		t.Fatalf("second Get: %v", err)
+	}
+	if host.ID != "h1" {
+		t.Errorf("second Get returned %+v", host)
+	}
+	if n := fake.count(http.MethodGet, "/host-store/api/v1/hosts/h1"); n != 2 {
+		t.Errorf("made %d API requests, want 2", n)
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	const hostPath = "/host-store/api/v1/hosts/h1"
+
+	tests := []struct {
+		name       string
+		write      func(reader, writer *fakeConnectorPair)
+		invalidate bool
+	}{
+		{
+			name:       "resource PUT",
+			write:      func(r, w *fakeConnectorPair) { w.put(hostPath) },
+			invalidate: true,
+		},
+		{
+			name:       "resource DELETE",
+			write:      func(r, w *fakeConnectorPair) { w.delete(hostPath) },
+			invalidate: true,
+		},
+		{
+			name:       "resource POST",
+			write:      func(r, w *fakeConnectorPair) { w.post("/host-store/api/v1/hosts") },
+			invalidate: true,
+		},
+		{
+			name:       "resource search",
+			write:      func(r, w *fakeConnectorPair) { w.post("/host-store/api/v1/hosts/search") },
+			invalidate: false,
+		},
+		{
+			name:       "data source POST",
+			write:      func(r, w *fakeConnectorPair) { r.post("/authorizer/api/v1/deploy/trusted-clients/c1/sessions") },
+			invalidate: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{
+				respond: func(method, path string) (interface{}, error) {
+					return testHost{ID: "h1"}, nil
+				},
+			}
+			cache := NewCache()
+			reader := &fakeConnectorPair{t: t, connector: *cache.Reader(fake)}
+			writer := &fakeConnectorPair{t: t, connector: *cache.Writer(fake)}
+
+			reader.get(hostPath)
+			tt.write(reader, writer)
+			reader.get(hostPath)
+
+			want := 1
+			if tt.invalidate {
+				want = 2
+			}
+			if n := fake.count(http.MethodGet, hostPath); n != want {
+				t.Errorf("made %d GET requests, want %d", n, want)
+			}
+		})
+	}
+}
+
+func TestCacheQueries(t *testing.T) {
+	const searchPath = "/host-store/api/v1/hosts/search"
+	fake := &fakeConnector{
+		respond: func(method, path string) (interface{}, error) {
+			return []testHost{{ID: "h1"}}, nil
+		},
+	}
+	reader := *NewCache().Reader(fake)
+
+	search := func(keywords string) {
+		var hosts []testHost
+		body := map[string]string{"keywords": keywords}
+		if _, err := reader.URL(searchPath).Post(body, &hosts); err != nil {
+			t.Fatalf("Post: %v", err)
+		}
+		if len(hosts) != 1 {
+			t.Fatalf("search returned %+v", hosts)
+		}
+	}
+	search("web")
+	search("web")
+	search("db")
+
+	if n := fake.count(http.MethodPost, searchPath); n != 2 {
+		t.Errorf("made %d search requests for two distinct queries, want 2", n)
+	}
+}
+
+func TestIsQueryPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/host-store/api/v1/hosts/search", true},
+		{"/role-store/api/v1/users/search?offset=0&limit=100", true},
+		{"/role-store/api/v1/roles/resolve", true},
+		{"/host-store/api/v1/hosts", false},
+		{"/role-store/api/v1/roles/r1/principalkeys/generate", false},
+		{"/authorizer/api/v1/deploy/trusted-clients/c1/sessions", false},
+		{"/vault/api/v1/secrets/search-term", false},
+		{"/host-store/api/v1/hosts/h1?x=search", false},
+	}
+
+	for _, tt := range tests {
+		if got := isQueryPath(tt.path); got != tt.want {
+			t.Errorf("isQueryPath(%q) = %t, want %t", tt.path, got, tt.want)
+		}
+	}
+}
+
+// fakeConnectorPair issues requests through a wrapped connector and fails
+// the test on errors.
+type fakeConnectorPair struct {
+	t         *testing.T
+	connector restapi.Connector
+}
+
+func (p *fakeConnectorPair) get(path string) {
+	var host testHost
+	if _, err := p.connector.URL(path).Get(&host); err != nil {
+		p.t.Fatalf("GET %s: %v", path, err)
+	}
+}
+
+func (p *fakeConnectorPair) put(path string) {
+	if _, err := p.connector.URL(path).Put(testHost{ID: "h1"}); err != nil {
+		p.t.Fatalf("PUT %s: %v", path, err)
+	}
+}
+
+func (p *fakeConnectorPair) post(path string) {
+	var out testHost
+	if _, err := p.connector.URL(path).Post(testHost{ID: "h1"}, &out); err != nil {
+		p.t.Fatalf("POST %s: %v", path, err)
+	}
+}
+
+func (p *fakeConnectorPair) delete(path string) {
+	if _, err := p.connector.URL(path).Delete(); err != nil {
+		p.t.Fatalf("DELETE %s: %v", path, err)
+	}
+}
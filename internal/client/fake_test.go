@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+// fakeConnector answers requests with respond and records every request it
+// receives as "METHOD path".
+type fakeConnector struct {
+	mu       sync.Mutex
+	requests []string
+	delay    time.Duration
+	respond  func(method, path string) (interface{}, error)
+}
+
+func (c *fakeConnector) URL(templatePath string, args ...interface{}) restapi.CURL {
+	return &fakeCURL{connector: c, path: fmt.Sprintf(templatePath, args...)}
+}
+
+// count returns how many requests were made with the given method and path.
+func (c *fakeConnector) count(method, path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, r := range c.requests {
+		if r == method+" "+path {
+			n++
+		}
+	}
+	return n
+}
+
+func (c *fakeConnector) do(method, path string, out interface{}) (http.Header, error) {
+	c.mu.Lock()
+	c.requests = append(c.requests, method+" "+path)
+	c.mu.Unlock()
+
+	if c.delay > 0 {
+		time.Sleep(c.delay)
+	}
+
+	var body interface{}
+	if c.respond != nil {
+		var err error
+		if body, err = c.respond(method, path); err != nil {
+			return nil, err
+		}
+	}
+	if out != nil && body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, out); err != nil {
+			return nil, err
+		}
+	}
+	return http.Header{}, nil
+}
+
+type fakeCURL struct {
+	connector *fakeConnector
+	path      string
+}
+
+func (curl *fakeCURL) Query(interface{}) restapi.CURL            { return curl }
+func (curl *fakeCURL) Header(string, string) restapi.CURL        { return curl }
+func (curl *fakeCURL) CookieJar(jar http.CookieJar) restapi.CURL { return curl }
+
+func (curl *fakeCURL) Status(...int) (http.Header, error) {
+	return curl.connector.do(http.MethodGet, curl.path, nil)
+}
+
+func (curl *fakeCURL) Get(in interface{}) (http.Header, error) {
+	return curl.connector.do(http.MethodGet, curl.path, in)
+}
+
+func (curl *fakeCURL) Put(eg interface{}, in ...interface{}) (http.Header, error) {
+	return curl.connector.do(http.MethodPut, curl.path, first(in))
+}
+
+func (curl *fakeCURL) Post(eg interface{}, in ...interface{}) (http.Header, error) {
+	return curl.connector.do(http.MethodPost, curl.path, first(in))
+}
+
+func (curl *fakeCURL) Delete(in ...interface{}) (http.Header, error) {
+	return curl.connector.do(http.MethodDelete, curl.path, first(in))
+}
+
+func (curl *fakeCURL) Fetch() ([]byte, error) {
+	_, err := curl.connector.do(http.MethodGet, curl.path, nil)
+	return nil, err
+}
+
+func (curl *fakeCURL) Download(string) error {
+	_, err := curl.connector.do(http.MethodGet, curl.path, nil)
+	return err
+}
+
+func first(in []interface{}) interface{} {
+	if len(in) == 0 {
+		return nil
+	}
+	return in[0]
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+// userRolesPath is the role-store endpoint that replaces the explicit role
+// grants of a user. Revoking a grant is a PUT without that role.
+const userRolesPath = "/role-store/api/v1/users/%s/roles"
+
+// Guard rejects requests that the provider configuration does not allow
+// before they are sent.
+//
+// Protected IDs cover two kinds of requests:
+//   - DELETE requests with a protected ID anywhere in the path, which delete
+//     the object itself or one of its sub-objects, such as a principal key
+//     of a role.
+//   - Updates of the explicit roles of a user that revoke a protected role,
+//     or revoke any role of a protected user.
+//
+// Other updates of protected objects are allowed.
+type Guard struct {
+	protected map[string]bool
+	readOnly  bool
+}
+
+// NewGuard returns a guard that refuses to delete the objects with the
+// given IDs. A read-only guard refuses every request that changes data.
+func NewGuard(protectedIDs []string, readOnly bool) *Guard {
+	g := &Guard{protected: make(map[string]bool, len(protectedIDs)), readOnly: readOnly}
+	for _, id := range protectedIDs {
+		g.protected[id] = true
+	}
+	return g
+}
+
+// Wrap returns a connector whose requests go through the guard.
+func (g *Guard) Wrap(connector restapi.Connector) restapi.Connector {
+	return &guardedConnector{inner: connector, guard: g}
+}
+
+// CheckDelete returns an error if the object with the given ID must not be
+// deleted.
+func (g *Guard) CheckDelete(id string) error {
+	if g.readOnly {
+		return fmt.Errorf("the provider is in read-only mode (read_only or PRIVX_READ_ONLY), refusing to delete %s", id)
+	}
+	if g.protected[id] {
+		return fmt.Errorf("%s is listed in the provider protected_ids and cannot be deleted", id)
+	}
+	return nil
+}
+
+// CheckDelete returns an error if connector is guarded and its guard does
+// not allow deleting the object with the given ID. It lets resources refuse
+// a delete before making any other changes.
+func CheckDelete(connector restapi.Connector, id string) error {
+	if c, ok := connector.(*guardedConnector); ok {
+		return c.guard.CheckDelete(id)
+	}
+	return nil
+}
+
+type guardedConnector struct {
+	inner restapi.Connector
+	guard *Guard
+}
+
+func (c *guardedConnector) URL(templatePath string, args ...interface{}) restapi.CURL {
+	return &guardedCURL{
+		CURL:         c.inner.URL(templatePath, args...),
+		connector:    c.inner,
+		guard:        c.guard,
+		templatePath: templatePath,
+		args:         args,
+	}
+}
+
+type guardedCURL struct {
+	restapi.CURL
+	connector    restapi.Connector
+	guard        *Guard
+	templatePath string
+	args         []interface{}
+}
+
+// check refuses requests that change data in read-only mode, and DELETE
+// requests with a protected object anywhere in the path.
+func (curl *guardedCURL) check(method string) error {
+	if curl.guard.readOnly && (method != http.MethodPost || !isQueryPath(curl.templatePath)) {
+		return fmt.Errorf("the provider is in read-only mode (read_only or PRIVX_READ_ONLY), refusing %s %s",
+			method, fmt.Sprintf(curl.templatePath, curl.args...))
+	}
+	if method != http.MethodDelete {
+		return nil
+	}
+	for _, arg := range curl.args {
+		if err := curl.guard.CheckDelete(fmt.Sprint(arg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUserRoles refuses an update of the explicit roles of a user that
+// revokes a protected role, or any role of a protected user. The current
+// grants are read first to find the revoked roles.
+func (curl *guardedCURL) checkUserRoles(body interface{}) error {
+	if curl.templatePath != userRolesPath || len(curl.guard.protected) == 0 || len(curl.args) != 1 {
+		return nil
+	}
+	userID := fmt.Sprint(curl.args[0])
+
+	type grant struct {
+		ID       string `json:"id"`
+		Explicit bool   `json:"explicit"`
+	}
+	var current struct {
+		Items []grant `json:"items"`
+	}
+	if _, err := curl.connector.URL(userRolesPath, userID).Get(&current); err != nil {
+		return fmt.Errorf("unable to read roles of user %s to check protected_ids: %w", userID, err)
+	}
+
+	var desired []grant
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &desired); err != nil {
+		return err
+	}
+	kept := make(map[string]bool, len(desired))
+	for _, role := range desired {
+		kept[role.ID] = true
+	}
+
+	for _, role := range current.Items {
+		if !role.Explicit || kept[role.ID] {
+			continue
+		}
+		if curl.guard.protected[userID] {
+			return fmt.Errorf("%s is listed in the provider protected_ids, refusing to revoke role %s from it", userID, role.ID)
+		}
+		if curl.guard.protected[role.ID] {
+			return fmt.Errorf("%s is listed in the provider protected_ids, refusing to revoke it from user %s", role.ID, userID)
+		}
+	}
+	return nil
+}
+
+func (curl *guardedCURL) Query(data interface{}) restapi.CURL {
+	curl.CURL = curl.CURL.Query(data)
+	return curl
+}
+
+func (curl *guardedCURL) Header(head, value string) restapi.CURL {
+	curl.CURL = curl.CURL.Header(head, value)
+	return curl
+}
+
+func (curl *guardedCURL) CookieJar(jar http.CookieJar) restapi.CURL {
+	curl.CURL = curl.CURL.CookieJar(jar)
+	return curl
+}
+
+func (curl *guardedCURL) Put(eg interface{}, in ...interface{}) (http.Header, error) {
+	if err := curl.check(http.MethodPut); err != nil {
+		return nil, err
+	}
+	if err := curl.checkUserRoles(eg); err != nil {
+		return nil, err
+	}
+	return curl.CURL.Put(eg, in...)
+}
+
+func (curl *guardedCURL) Post(eg interface{}, in ...interface{}) (http.Header, error) {
+	if err := curl.check(http.MethodPost); err != nil {
+		return nil, err
+	}
+	return curl.CURL.Post(eg, in...)
+}
+
+func (curl *guardedCURL) Delete(in ...interface{}) (http.Header, error) {
+	if err := curl.check(http.MethodDelete); err != nil {
+		return nil, err
+	}
+	return curl.CURL.Delete(in...)
+}
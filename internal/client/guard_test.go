@@ -0,0 +1,201 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+)
+
+const (
+	protectedID = "11111111-1111-1111-1111-111111111111"
+	otherID     = "22222222-2222-2222-2222-222222222222"
+	userID      = "33333333-3333-3333-3333-333333333333"
+)
+
+// userRoles answers the user roles endpoint with the given explicit grants.
+func userRoles(roleIDs ...string) func(method, path string) (interface{}, error) {
+	return func(method, path string) (interface{}, error) {
+		if method != http.MethodGet || !strings.HasSuffix(path, "/roles") {
+			return nil, nil
+		}
+		items := []rolestore.Role{{ID: "implicit", Explicit: false}}
+		for _, id := range roleIDs {
+			items = append(items, rolestore.Role{ID: id, Explicit: true})
+		}
+		return map[string]interface{}{"count": len(items), "items": items}, nil
+	}
+}
+
+// Hosts are deleted with DeleteHost by privx_host and by privx_host_set,
+// both on destroy and when a host is removed from the set.
+func TestGuardProtectedDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		delete  func(fake *fakeConnector) error
+		path    string
+		refused bool
+	}{
+		{
+			name: "protected host",
+			delete: func(fake *fakeConnector) error {
+				return hoststore.New(NewGuard([]string{protectedID}, false).Wrap(fake)).DeleteHost(protectedID)
+			},
+			path:    "/host-store/api/v1/hosts/" + protectedID,
+			refused: true,
+		},
+		{
+			name: "other host",
+			delete: func(fake *fakeConnector) error {
+				return hoststore.New(NewGuard([]string{protectedID}, false).Wrap(fake)).DeleteHost(otherID)
+			},
+			path: "/host-store/api/v1/hosts/" + otherID,
+		},
+		{
+			name: "principal key of protected role",
+			delete: func(fake *fakeConnector) error {
+				return rolestore.New(NewGuard([]string{protectedID}, false).Wrap(fake)).DeletePrincipalKey(protectedID, otherID)
+			},
+			path:    "/role-store/api/v1/roles/" + protectedID + "/principalkeys/" + otherID,
+			refused: true,
+		},
+		{
+			name: "protected principal key",
+			delete: func(fake *fakeConnector) error {
+				return rolestore.New(NewGuard([]string{protectedID}, false).Wrap(fake)).DeletePrincipalKey(otherID, protectedID)
+			},
+			path:    "/role-store/api/v1/roles/" + otherID + "/principalkeys/" + protectedID,
+			refused: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{}
+			err := tt.delete(fake)
+
+			if tt.refused {
+				if err == nil || !strings.Contains(err.Error(), "protected_ids") {
+					t.Errorf("got error %v, want a protected_ids error", err)
+				}
+				if n := fake.count(http.MethodDelete, tt.path); n != 0 {
+					t.Errorf("refused delete was sent %d times", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Delete: %v", err)
+			}
+			if n := fake.count(http.MethodDelete, tt.path); n != 1 {
+				t.Errorf("delete sent %d times, want 1", n)
+			}
+		})
+	}
+}
+
+// Role members are revoked by writing the remaining explicit grants of the
+// user, the way privx_role_member, privx_role_members and a disabled
+// privx_local_user do.
+func TestGuardProtectedRoleRevoke(t *testing.T) {
+	tests := []struct {
+		name      string
+		protected []string
+		current   []string
+		remaining []string
+		refused   bool
+	}{
+		{"revoke protected role", []string{protectedID}, []string{protectedID, otherID}, []string{otherID}, true},
+		{"revoke all roles of protected user", []string{userID}, []string{otherID}, []string{}, true},
+		{"revoke other role", []string{protectedID}, []string{protectedID, otherID}, []string{protectedID}, false},
+		{"grant role to protected user", []string{userID}, []string{otherID}, []string{otherID, protectedID}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{respond: userRoles(tt.current...)}
+			roles := make([]rolestore.Role, 0, len(tt.remaining))
+			for _, id := range tt.remaining {
+				roles = append(roles, rolestore.Role{ID: id, Explicit: true})
+			}
+
+			err := rolestore.New(NewGuard(tt.protected, false).Wrap(fake)).UpdateUserRoles(userID, roles)
+
+			path := "/role-store/api/v1/users/" + userID + "/roles"
+			if tt.refused {
+				if err == nil || !strings.Contains(err.Error(), "protected_ids") {
+					t.Errorf("got error %v, want a protected_ids error", err)
+				}
+				if n := fake.count(http.MethodPut, path); n != 0 {
+					t.Errorf("refused update was sent %d times", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("UpdateUserRoles: %v", err)
+			}
+			if n := fake.count(http.MethodPut, path); n != 1 {
+				t.Errorf("update sent %d times, want 1", n)
+			}
+		})
+	}
+}
+
+func TestGuardWithoutProtectedIDsDoesNotReadRoles(t *testing.T) {
+	fake := &fakeConnector{respond: userRoles(otherID)}
+
+	if err := rolestore.New(NewGuard(nil, false).Wrap(fake)).UpdateUserRoles(userID, nil); err != nil {
+		t.Fatalf("UpdateUserRoles: %v", err)
+	}
+	if n := fake.count(http.MethodGet, "/role-store/api/v1/users/"+userID+"/roles"); n != 0 {
+		t.Errorf("guard read the user roles %d times, want 0", n)
+	}
+}
+
+func TestGuardReadOnly(t *testing.T) {
+	fake := &fakeConnector{}
+	connector := NewGuard(nil, true).Wrap(fake)
+
+	if _, err := connector.URL("/host-store/api/v1/hosts/%s", otherID).Get(&testHost{}); err != nil {
+		t.Errorf("Get: %v", err)
+	}
+	if _, err := connector.URL("/host-store/api/v1/hosts/search").Post(testHost{}, &testHost{}); err != nil {
+		t.Errorf("search: %v", err)
+	}
+
+	writes := []struct {
+		method string
+		send   func() error
+	}{
+		{http.MethodPost, func() error { _, err := connector.URL("/host-store/api/v1/hosts").Post(testHost{}); return err }},
+		{http.MethodPut, func() error { _, err := connector.URL("/host-store/api/v1/hosts/x").Put(testHost{}); return err }},
+		{http.MethodDelete, func() error { _, err := connector.URL("/host-store/api/v1/hosts/x").Delete(); return err }},
+	}
+	for _, w := range writes {
+		err := w.send()
+		if err == nil || !strings.Contains(err.Error(), "read-only mode") {
+			t.Errorf("%s: got error %v, want a read-only mode error", w.method, err)
+		}
+	}
+
+	for _, r := range fake.requests {
+		if !strings.HasPrefix(r, http.MethodGet) && r != "POST /host-store/api/v1/hosts/search" {
+			t.Errorf("read-only guard sent %s", r)
+		}
+	}
+}
+
+// Data sources read through the cache and the guard, so the download
+// sessions they open are refused in read-only mode.
+func TestGuardReadOnlyDataSourceSession(t *testing.T) {
+	fake := &fakeConnector{}
+	reader := NewGuard(nil, true).Wrap(*NewCache().Reader(fake))
+
+	_, err := authorizer.New(reader).GetDeployScriptSessions(otherID)
+	if err == nil || !strings.Contains(err.Error(), "read-only mode") {
+		t.Errorf("got error %v, want a read-only mode error", err)
+	}
+	if len(fake.requests) != 0 {
+		t.Errorf("read-only guard sent %v", fake.requests)
+	}
+}
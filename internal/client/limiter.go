@@ -0,0 +1,215 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+// throttleRetries is how many times a throttled request is sent again.
+const throttleRetries = 3
+
+// throttleBackoff is the wait before the first retry; it doubles after every
+// retry.
+var throttleBackoff = time.Second
+
+// Limiter bounds the number of PrivX API requests in flight across all
+// resources and data sources, retries throttled requests and records
+// request metrics.
+type Limiter struct {
+	slots   chan struct{}
+	metrics *Metrics
+}
+
+// NewLimiter returns a limiter that allows at most maxConcurrent requests
+// at a time. Zero means no limit.
+func NewLimiter(maxConcurrent int64, metrics *Metrics) *Limiter {
+	l := &Limiter{metrics: metrics}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// Wrap returns a connector whose requests go through the limiter.
+func (l *Limiter) Wrap(connector restapi.Connector) restapi.Connector {
+	return &limitedConnector{inner: connector, limiter: l}
+}
+
+func (l *Limiter) acquire() {
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+}
+
+func (l *Limiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// do sends a request, waiting for a free slot first. Throttled requests are
+// retried with backoff; the slot is given up while waiting so that other
+// requests can proceed.
+func (l *Limiter) do(method, endpoint string, send func() error) error {
+	start := time.Now()
+	backoff := throttleBackoff
+
+	var err error
+	retries := 0
+	for {
+		l.acquire()
+		err = send()
+		l.release()
+
+		if !isThrottled(method, err) || retries == throttleRetries {
+			break
+		}
+		retries++
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	l.metrics.record(endpoint, time.Since(start), retries, err)
+	return err
+}
+
+// isThrottled reports whether a request that failed with err was throttled
+// and may be sent again. A 429 response means PrivX rejected the request
+// without processing it, so any method is retried. A 503 response may come
+// from a proxy that already forwarded the request, so only idempotent
+// methods are retried; a POST could otherwise create a duplicate object.
+func isThrottled(method string, err error) bool {
+	switch httpStatus(err) {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return method != http.MethodPost
+	}
+	return false
+}
+
+// httpStatus returns the HTTP status code of an SDK error, or 0 if err does
+// not carry one. The SDK reports the status only for responses without a
+// JSON error body, as "HTTP error: <code> <text>"; responses with a JSON
+// error body are reported by error code and are never retried.
+func httpStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+	msg, ok := strings.CutPrefix(err.Error(), "HTTP error: ")
+	if !ok {
+		return 0
+	}
+	code, _, _ := strings.Cut(msg, " ")
+	status, err := strconv.Atoi(code)
+	if err != nil {
+		return 0
+	}
+	return status
+}
+
+type limitedConnector struct {
+	inner   restapi.Connector
+	limiter *Limiter
+}
+
+func (c *limitedConnector) URL(templatePath string, args ...interface{}) restapi.CURL {
+	return &limitedCURL{
+		connector:    c,
+		templatePath: templatePath,
+		args:         args,
+	}
+}
+
+// limitedCURL records the request it builds so that it can be sent again
+// on a fresh SDK request when it is retried.
+type limitedCURL struct {
+	connector    *limitedConnector
+	templatePath string
+	args         []interface{}
+	build        []func(restapi.CURL) restapi.CURL
+}
+
+func (curl *limitedCURL) Query(data interface{}) restapi.CURL {
+	curl.build = append(curl.build, func(c restapi.CURL) restapi.CURL { return c.Query(data) })
+	return curl
+}
+
+func (curl *limitedCURL) Header(head, value string) restapi.CURL {
+	curl.build = append(curl.build, func(c restapi.CURL) restapi.CURL { return c.Header(head, value) })
+	return curl
+}
+
+func (curl *limitedCURL) CookieJar(jar http.CookieJar) restapi.CURL {
+	curl.build = append(curl.build, func(c restapi.CURL) restapi.CURL { return c.CookieJar(jar) })
+	return curl
+}
+
+func (curl *limitedCURL) request() restapi.CURL {
+	c := curl.connector.inner.URL(curl.templatePath, curl.args...)
+	for _, build := range curl.build {
+		c = build(c)
+	}
+	return c
+}
+
+func (curl *limitedCURL) send(method string, call func(restapi.CURL) (http.Header, error)) (http.Header, error) {
+	var header http.Header
+	err := curl.connector.limiter.do(method, method+" "+curl.templatePath, func() error {
+		var err error
+		header, err = call(curl.request())
+		return err
+	})
+	return header, err
+}
+
+func (curl *limitedCURL) Status(status ...int) (http.Header, error) {
+	return curl.send(http.MethodGet, func(c restapi.CURL) (http.Header, error) {
+		return c.Status(status...)
+	})
+}
+
+func (curl *limitedCURL) Get(in interface{}) (http.Header, error) {
+	return curl.send(http.MethodGet, func(c restapi.CURL) (http.Header, error) {
+		return c.Get(in)
+	})
+}
+
+func (curl *limitedCURL) Put(eg interface{}, in ...interface{}) (http.Header, error) {
+	return curl.send(http.MethodPut, func(c restapi.CURL) (http.Header, error) {
+		return c.Put(eg, in...)
+	})
+}
+
+func (curl *limitedCURL) Post(eg interface{}, in ...interface{}) (http.Header, error) {
+	return curl.send(http.MethodPost, func(c restapi.CURL) (http.Header, error) {
+		return c.Post(eg, in...)
+	})
+}
+
+func (curl *limitedCURL) Delete(in ...interface{}) (http.Header, error) {
+	return curl.send(http.MethodDelete, func(c restapi.CURL) (http.Header, error) {
+		return c.Delete(in...)
+	})
+}
+
+func (curl *limitedCURL) Fetch() ([]byte, error) {
+	var body []byte
+	_, err := curl.send(http.MethodGet, func(c restapi.CURL) (http.Header, error) {
+		var err error
+		body, err = c.Fetch()
+		return nil, err
+	})
+	return body, err
+}
+
+func (curl *limitedCURL) Download(filename string) error {
+	_, err := curl.send(http.MethodGet, func(c restapi.CURL) (http.Header, error) {
+		return nil, c.Download(filename)
+	})
+	return err
+}
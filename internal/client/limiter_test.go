@@ -0,0 +1,196 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastBackoff shortens the throttling backoff for the duration of a test.
+func fastBackoff(t *testing.T, backoff time.Duration) {
+	saved := throttleBackoff
+	throttleBackoff = backoff
+	t.Cleanup(func() { throttleBackoff = saved })
+}
+
+func TestLimiterRetries(t *testing.T) {
+	fastBackoff(t, time.Millisecond)
+
+	tests := []struct {
+		name   string
+		method string
+		err    string
+		sent   int
+	}{
+		{"get 429", http.MethodGet, "HTTP error: 429 Too Many Requests", 1 + throttleRetries},
+		{"post 429", http.MethodPost, "HTTP error: 429 Too Many Requests", 1 + throttleRetries},
+		{"get 503", http.MethodGet, "HTTP error: 503 Service Unavailable", 1 + throttleRetries},
+		{"put 503", http.MethodPut, "HTTP error: 503 Service Unavailable", 1 + throttleRetries},
+		{"delete 503", http.MethodDelete, "HTTP error: 503 Service Unavailable", 1 + throttleRetries},
+		{"post 503", http.MethodPost, "HTTP error: 503 Service Unavailable", 1},
+		{"get 500", http.MethodGet, "HTTP error: 500 Internal Server Error", 1},
+		{"json error body", http.MethodGet, "error: TOO_MANY_REQUESTS, message: 429", 1},
+		{"transport error", http.MethodGet, "dial tcp: connection refused", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{
+				respond: func(method, path string) (interface{}, error) {
+					return nil, errors.New(tt.err)
+				},
+			}
+			metrics := NewMetrics()
+			curl := NewLimiter(0, metrics).Wrap(fake).URL("/role-store/api/v1/roles")
+
+			var err error
+			switch tt.method {
+			case http.MethodGet:
+				_, err = curl.Get(&testHost{})
+			case http.MethodPut:
+				_, err = curl.Put(testHost{})
+			case http.MethodPost:
+				_, err = curl.Post(testHost{})
+			case http.MethodDelete:
+				_, err = curl.Delete()
+			}
+
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+			if n := fake.count(tt.method, "/role-store/api/v1/roles"); n != tt.sent {
+				t.Errorf("request sent %d times, want %d", n, tt.sent)
+			}
+			e := metrics.endpoints[tt.method+" /role-store/api/v1/roles"]
+			if e == nil || e.requests != 1 || e.errors != 1 || e.retries != int64(tt.sent-1) {
+				t.Errorf("got metrics %+v, want 1 request, 1 error and %d retries", e, tt.sent-1)
+			}
+		})
+	}
+}
+
+func TestLimiterRetrySucceeds(t *testing.T) {
+	fastBackoff(t, time.Millisecond)
+
+	attempts := 0
+	fake := &fakeConnector{
+		respond: func(method, path string) (interface{}, error) {
+			attempts++
+			if attempts <= 2 {
+				return nil, errors.New("HTTP error: 429 Too Many Requests")
+			}
+			return testHost{ID: "h1"}, nil
+		},
+	}
+	metrics := NewMetrics()
+
+	var host testHost
+	if _, err := NewLimiter(0, metrics).Wrap(fake).URL("/host-store/api/v1/hosts/%s", "h1").Get(&host); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if host.ID != "h1" {
+		t.Errorf("got %+v", host)
+	}
+	e := metrics.endpoints["GET /host-store/api/v1/hosts/%s"]
+	if e == nil || e.requests != 1 || e.errors != 0 || e.retries != 2 {
+		t.Errorf("got metrics %+v, want 1 request, no errors and 2 retries", e)
+	}
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	const maxConcurrent = 2
+
+	var inFlight, peak int32
+	fake := &fakeConnector{
+		respond: func(method, path string) (interface{}, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return nil, nil
+		},
+	}
+	connector := NewLimiter(maxConcurrent, NewMetrics()).Wrap(fake)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := connector.URL("/host-store/api/v1/hosts").Get(&testHost{}); err != nil {
+				t.Errorf("Get: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak != maxConcurrent {
+		t.Errorf("%d requests in flight at most, want %d", peak, maxConcurrent)
+	}
+}
+
+func TestLimiterReleasesSlotDuringBackoff(t *testing.T) {
+	fastBackoff(t, 100*time.Millisecond)
+
+	throttled := false
+	fake := &fakeConnector{
+		respond: func(method, path string) (interface{}, error) {
+			if path == "/a" && !throttled {
+				throttled = true
+				return nil, errors.New("HTTP error: 429 Too Many Requests")
+			}
+			return nil, nil
+		},
+	}
+	connector := NewLimiter(1, NewMetrics()).Wrap(fake)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := connector.URL("/a").Get(&testHost{}); err != nil {
+			t.Errorf("Get /a: %v", err)
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if _, err := connector.URL("/b").Get(&testHost{}); err != nil {
+		t.Errorf("Get /b: %v", err)
+	}
+	wg.Wait()
+
+	want := []string{"GET /a", "GET /b", "GET /a"}
+	if len(fake.requests) != len(want) {
+		t.Fatalf("got requests %v, want %v", fake.requests, want)
+	}
+	for i := range want {
+		if fake.requests[i] != want[i] {
+			t.Fatalf("got requests %v, want %v", fake.requests, want)
+		}
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{errors.New("HTTP error: 429 Too Many Requests"), http.StatusTooManyRequests},
+		{errors.New("HTTP error: 503 Service Unavailable (unexpected response body: invalid character '<')"), http.StatusServiceUnavailable},
+		{errors.New("error: NOT_FOUND, message: HTTP error: 429 "), 0},
+		{errors.New("HTTP error: unknown"), 0},
+		{errors.New("request failed after 2 tries"), 0},
+	}
+	for _, tt := range tests {
+		if got := httpStatus(tt.err); got != tt.want {
+			t.Errorf("httpStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
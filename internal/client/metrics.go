@@ -0,0 +1,104 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics counts PrivX API requests per endpoint. An endpoint is the HTTP
+// method and the SDK's path template, so requests for different object IDs
+// add up to one endpoint.
+type Metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+	trackers  map[*Metrics]struct{}
+}
+
+type endpointMetrics struct {
+	requests int64
+	errors   int64
+	retries  int64
+	total    time.Duration
+	max      time.Duration
+}
+
+// NewMetrics returns empty request metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{endpoints: map[string]*endpointMetrics{}}
+}
+
+// Track returns metrics that also count every request recorded in m until
+// stop is called. Requests of operations that run at the same time are
+// counted by each of them.
+func (m *Metrics) Track() (tracked *Metrics, stop func()) {
+	tracked = NewMetrics()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.trackers == nil {
+		m.trackers = map[*Metrics]struct{}{}
+	}
+	m.trackers[tracked] = struct{}{}
+	return tracked, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		delete(m.trackers, tracked)
+	}
+}
+
+// record adds one finished request, including the retries it took.
+func (m *Metrics) record(endpoint string, elapsed time.Duration, retries int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for tracked := range m.trackers {
+		tracked.record(endpoint, elapsed, retries, err)
+	}
+
+	e, ok := m.endpoints[endpoint]
+	if !ok {
+		e = &endpointMetrics{}
+		m.endpoints[endpoint] = e
+	}
+	e.requests++
+	e.retries += int64(retries)
+	if err != nil {
+		e.errors++
+	}
+	e.total += elapsed
+	if elapsed > e.max {
+		e.max = elapsed
+	}
+}
+
+// Requests returns the number of requests recorded so far.
+func (m *Metrics) Requests() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for _, e := range m.endpoints {
+		n += e.requests
+	}
+	return n
+}
+
+// Summary returns the metrics as log fields keyed by endpoint.
+func (m *Metrics) Summary() map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := make(map[string]interface{}, len(m.endpoints))
+	for name, e := range m.endpoints {
+		summary[name] = map[string]interface{}{
+			"requests":       e.requests,
+			"errors":         e.errors,
+			"retries":        e.retries,
+			"avg_latency_ms": e.total.Milliseconds() / e.requests,
+			"max_latency_ms": e.max.Milliseconds(),
+		}
+	}
+	return summary
+}
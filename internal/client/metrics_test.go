@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMetricsTrack(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.record("GET /a", time.Millisecond, 0, nil)
+
+	tracked, stop := metrics.Track()
+	metrics.record("GET /a", 3*time.Millisecond, 1, nil)
+	metrics.record("POST /b", time.Millisecond, 0, errors.New("failed"))
+	stop()
+	metrics.record("GET /a", time.Millisecond, 0, nil)
+
+	if n := metrics.Requests(); n != 4 {
+		t.Errorf("provider metrics counted %d requests, want 4", n)
+	}
+	if n := tracked.Requests(); n != 2 {
+		t.Errorf("tracked metrics counted %d requests, want 2", n)
+	}
+	if e := tracked.endpoints["GET /a"]; e == nil || e.requests != 1 || e.retries != 1 || e.max != 3*time.Millisecond {
+		t.Errorf("got GET /a metrics %+v", e)
+	}
+	if e := tracked.endpoints["POST /b"]; e == nil || e.requests != 1 || e.errors != 1 {
+		t.Errorf("got POST /b metrics %+v", e)
+	}
+}
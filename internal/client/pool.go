@@ -1,6 +1,7 @@
 package client
 
 import (
+	"os"
 	"sync"
 	"time"
 
@@ -24,6 +25,19 @@ type ConnectionConfig struct {
 	OAuthClientSecret string
 }
 
+// ConfigFromEnv reads the connection settings from the same environment
+// variables the provider uses.
+func ConfigFromEnv() ConnectionConfig {
+	return ConnectionConfig{
+		APIBaseURL:        os.Getenv("PRIVX_API_BASE_URL"),
+		BearerToken:       os.Getenv("PRIVX_API_BEARER_TOKEN"),
+		APIClientID:       os.Getenv("PRIVX_API_CLIENT_ID"),
+		APIClientSecret:   os.Getenv("PRIVX_API_CLIENT_SECRET"),
+		OAuthClientID:     os.Getenv("PRIVX_API_OAUTH_CLIENT_ID"),
+		OAuthClientSecret: os.Getenv("PRIVX_API_OAUTH_CLIENT_SECRET"),
+	}
+}
+
 var (
 	globalPool *ConnectionPool
 	poolMu     sync.Mutex
@@ -0,0 +1,71 @@
+package drift
+
+import (
+	"fmt"
+	"slices"
+
+	"terraform-provider-privx/internal/export"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/vault"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+// secretsPageSize is the page size used when listing secrets.
+const secretsPageSize = 100
+
+// Types are the resource types the report covers, in report order.
+var Types = []string{
+	"privx_access_group",
+	"privx_role",
+	"privx_secret",
+	"privx_workflow",
+	"privx_whitelist",
+	"privx_host",
+}
+
+// ListObjects lists the PrivX objects of the given resource types. Secrets
+// are listed with their metadata only.
+func ListObjects(connector restapi.Connector, types []string) ([]export.Object, error) {
+	var exportTypes []string
+	secrets := false
+	for _, t := range types {
+		switch {
+		case t == "privx_secret":
+			secrets = true
+		case slices.Contains(Types, t):
+			exportTypes = append(exportTypes, t)
+		default:
+			return nil, fmt.Errorf("unsupported resource type %q", t)
+		}
+	}
+
+	objects, err := export.NewLister(connector).List(exportTypes)
+	if err != nil {
+		return nil, err
+	}
+	if !secrets {
+		return objects, nil
+	}
+
+	client := vault.New(connector)
+	for offset := 0; ; offset += secretsPageSize {
+		page, err := client.GetSecrets(filters.Paging(offset, secretsPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("unable to list privx_secret objects: %w", err)
+		}
+		for _, s := range page.Items {
+			objects = append(objects, export.Object{
+				Type: "privx_secret",
+				ID:   s.Name,
+				Name: s.Name,
+				Fields: []export.Field{
+					{Name: "name", Value: s.Name},
+				},
+			})
+		}
+		if len(page.Items) < secretsPageSize || offset+len(page.Items) >= page.Count {
+			return objects, nil
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"terraform-provider-privx/internal/export"
+)
+
+// Report lists the differences between Terraform state and PrivX.
+type Report struct {
+	// Unmanaged objects exist in PrivX but in none of the state files.
+	Unmanaged []Entry `json:"unmanaged"`
+	// Missing objects are in state but no longer exist in PrivX.
+	Missing []Entry `json:"missing"`
+	// Modified objects have attributes in PrivX that differ from state.
+	Modified []Entry `json:"modified"`
+}
+
+// Entry is one object of a report.
+type Entry struct {
+	Type    string   `json:"type"`
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	Address string   `json:"address,omitempty"`
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Change is an attribute whose PrivX value differs from state.
+type Change struct {
+	Attribute string      `json:"attribute"`
+	State     interface{} `json:"state"`
+	PrivX     interface{} `json:"privx"`
+}
+
+type objectKey struct {
+	Type string
+	ID   string
+}
+
+// Compare reports the differences between the managed objects and the
+// PrivX objects of the given types.
+//
+// Only top-level string, number, bool and string list attributes are
+// compared; nested attributes are not.
+func Compare(managed []Managed, remote []export.Object, types []string) Report {
+	byKey := make(map[objectKey]Managed, len(managed))
+	for _, m := range managed {
+		key := objectKey{Type: m.Type, ID: m.ID}
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = m
+		}
+	}
+
+	report := Report{Unmanaged: []Entry{}, Missing: []Entry{}, Modified: []Entry{}}
+	found := make(map[objectKey]bool, len(remote))
+	for _, o := range remote {
+		key := objectKey{Type: o.Type, ID: o.ID}
+		found[key] = true
+
+		m, ok := byKey[key]
+		if !ok {
+			report.Unmanaged = append(report.Unmanaged, Entry{Type: o.Type, ID: o.ID, Name: o.Name})
+			continue
+		}
+		if m.Attributes == nil {
+			continue
+		}
+		if changes := compareAttributes(o, m.Attributes); len(changes) > 0 {
+			report.Modified = append(report.Modified, Entry{
+				Type:    o.Type,
+				ID:      o.ID,
+				Name:    o.Name,
+				Address: m.Address,
+				Changes: changes,
+			})
+		}
+	}
+
+	for key, m := range byKey {
+		if found[key] || !slices.Contains(types, key.Type) {
+			continue
+		}
+		report.Missing = append(report.Missing, Entry{
+			Type:    m.Type,
+			ID:      m.ID,
+			Name:    stateName(m.Attributes),
+			Address: m.Address,
+		})
+	}
+
+	for _, entries := range [][]Entry{report.Unmanaged, report.Missing, report.Modified} {
+		sortEntries(entries)
+	}
+	return report
+}
+
+func compareAttributes(o export.Object, attributes map[string]interface{}) []Change {
+	var changes []Change
+	for _, f := range o.Fields {
+		remote, ok := remoteValue(f.Value)
+		if !ok {
+			continue
+		}
+		state := stateValue(attributes[f.Name], remote)
+		if equal(state, remote) {
+			continue
+		}
+		changes = append(changes, Change{Attribute: f.Name, State: state, PrivX: remote})
+	}
+	return changes
+}
+
+// remoteValue returns the value of a PrivX attribute in the form state
+// values are decoded to.
+func remoteValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string, bool:
+		return v, true
+	case int64:
+		return float64(v), true
+	case export.Ref:
+		return v.ID, true
+	case []string:
+		sorted := append([]string{}, v...)
+		sort.Strings(sorted)
+		return sorted, true
+	}
+	return nil, false
+}
+
+// stateValue converts a state value to the type of the PrivX value. Null
+// attributes become zero values.
+func stateValue(value interface{}, like interface{}) interface{} {
+	switch like.(type) {
+	case string:
+		s, _ := value.(string)
+		return s
+	case bool:
+		b, _ := value.(bool)
+		return b
+	case float64:
+		n, _ := value.(float64)
+		return n
+	case []string:
+		list, _ := value.([]interface{})
+		out := []string{}
+		for _, e := range list {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+	return value
+}
+
+// equal compares two values. Strings holding JSON documents, such as role
+// source rules, are compared by content.
+func equal(state, remote interface{}) bool {
+	if reflect.DeepEqual(state, remote) {
+		return true
+	}
+
+	s, ok1 := state.(string)
+	r, ok2 := remote.(string)
+	if !ok1 || !ok2 {
+		return false
+	}
+	var sv, rv interface{}
+	if json.Unmarshal([]byte(s), &sv) != nil || json.Unmarshal([]byte(r), &rv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(sv, rv)
+}
+
+func stateName(attributes map[string]interface{}) string {
+	for _, name := range []string{"name", "common_name"} {
+		if s, ok := attributes[name].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Type != b.Type {
+			return slices.Index(Types, a.Type) < slices.Index(Types, b.Type)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteMarkdown writes the report as Markdown tables.
+func (r Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# PrivX Drift Report\n")
+
+	fmt.Fprintf(&b, "\n## Unmanaged Objects (%d)\n\n", len(r.Unmanaged))
+	if len(r.Unmanaged) == 0 {
+		b.WriteString("None.\n")
+	} else {
+		b.WriteString("| Type | Name | ID |\n|------|------|----|\n")
+		for _, e := range r.Unmanaged {
+			fmt.Fprintf(&b, "| `%s` | %s | `%s` |\n", e.Type, markdownCell(e.Name), e.ID)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Missing Objects (%d)\n\n", len(r.Missing))
+	if len(r.Missing) == 0 {
+		b.WriteString("None.\n")
+	} else {
+		b.WriteString("| Address | Name | ID |\n|---------|------|----|\n")
+		for _, e := range r.Missing {
+			fmt.Fprintf(&b, "| `%s` | %s | `%s` |\n", e.Address, markdownCell(e.Name), e.ID)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Modified Objects (%d)\n\n", len(r.Modified))
+	if len(r.Modified) == 0 {
+		b.WriteString("None.\n")
+	} else {
+		b.WriteString("| Address | Name | ID | Attributes |\n|---------|------|----|------------|\n")
+		for _, e := range r.Modified {
+			attributes := make([]string, 0, len(e.Changes))
+			for _, c := range e.Changes {
+				attributes = append(attributes, "`"+c.Attribute+"`")
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | `%s` | %s |\n", e.Address, markdownCell(e.Name), e.ID, strings.Join(attributes, ", "))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
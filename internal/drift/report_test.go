@@ -0,0 +1,149 @@
+package drift
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-privx/internal/export"
+)
+
+func TestCompare(t *testing.T) {
+	managed := []Managed{
+		{
+			Type:    "privx_role",
+			ID:      "r1",
+			Address: "privx_role.admins",
+			Attributes: map[string]interface{}{
+				"id":              "r1",
+				"name":            "admins",
+				"permit_agent":    false,
+				"access_group_id": "a1",
+				"permissions":     []interface{}{"roles-view", "hosts-view"},
+				"source_rules":    `{"type": "GROUP", "match": "ANY"}`,
+			},
+		},
+		{
+			Type:    "privx_role",
+			ID:      "r2",
+			Address: "privx_role.ops",
+			Attributes: map[string]interface{}{
+				"id":           "r2",
+				"name":         "ops",
+				"permit_agent": false,
+				"comment":      nil,
+			},
+		},
+		{Type: "privx_role", ID: "r3", Address: "privx_role.gone", Attributes: map[string]interface{}{"id": "r3", "name": "gone"}},
+		{Type: "privx_host", ID: "h1", Address: `privx_host_set.fleet.hosts["a"]`},
+		{Type: "privx_host", ID: "h9", Address: `privx_host_set.fleet.hosts["z"]`},
+	}
+	remote := []export.Object{
+		{
+			Type: "privx_role",
+			ID:   "r1",
+			Name: "admins",
+			Fields: []export.Field{
+				{Name: "name", Value: "admins"},
+				{Name: "permit_agent", Value: false},
+				{Name: "access_group_id", Value: export.Ref{Type: "privx_access_group", ID: "a1"}},
+				{Name: "permissions", Value: []string{"hosts-view", "roles-view"}},
+				{Name: "source_rules", Value: `{"match":"ANY","type":"GROUP"}`},
+				{Name: "principals", Value: []export.Nested{{{Name: "principal", Value: "root"}}}},
+			},
+		},
+		{
+			Type: "privx_role",
+			ID:   "r2",
+			Name: "ops",
+			Fields: []export.Field{
+				{Name: "name", Value: "operators"},
+				{Name: "permit_agent", Value: true},
+				{Name: "comment", Value: ""},
+			},
+		},
+		{Type: "privx_role", ID: "r4", Name: "manual"},
+		{Type: "privx_host", ID: "h1", Name: "web", Fields: []export.Field{{Name: "common_name", Value: "changed"}}},
+		{Type: "privx_access_group", ID: "a2", Name: "Unmanaged"},
+	}
+
+	got := Compare(managed, remote, []string{"privx_access_group", "privx_role"})
+
+	want := Report{
+		Unmanaged: []Entry{
+			{Type: "privx_access_group", ID: "a2", Name: "Unmanaged"},
+			{Type: "privx_role", ID: "r4", Name: "manual"},
+		},
+		Missing: []Entry{
+			{Type: "privx_role", ID: "r3", Name: "gone", Address: "privx_role.gone"},
+		},
+		Modified: []Entry{
+			{
+				Type:    "privx_role",
+				ID:      "r2",
+				Name:    "ops",
+				Address: "privx_role.ops",
+				Changes: []Change{
+					{Attribute: "name", State: "ops", PrivX: "operators"},
+					{Attribute: "permit_agent", State: false, PrivX: true},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestCompareEmpty(t *testing.T) {
+	got := Compare(nil, nil, Types)
+	if got.Unmanaged == nil || got.Missing == nil || got.Modified == nil {
+		t.Errorf("got nil report lists: %+v", got)
+	}
+}
+
+func TestStateValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		like  interface{}
+		want  interface{}
+	}{
+		{"string", "web", "", "web"},
+		{"null string", nil, "", ""},
+		{"bool", true, false, true},
+		{"null bool", nil, false, false},
+		{"number", float64(22), float64(0), float64(22)},
+		{"null number", nil, float64(0), float64(0)},
+		{"wrong type", "22", float64(0), float64(0)},
+		{"list", []interface{}{"b", "a"}, []string{}, []string{"a", "b"}},
+		{"null list", nil, []string{}, []string{}},
+		{"list with null", []interface{}{"a", nil}, []string{}, []string{"a"}},
+		{"other", map[string]interface{}{"a": "b"}, nil, map[string]interface{}{"a": "b"}},
+	}
+	for _, tt := range tests {
+		if got := stateValue(tt.value, tt.like); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: stateValue(%v, %T) = %#v, want %#v", tt.name, tt.value, tt.like, got, tt.want)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		state, remote interface{}
+		want          bool
+	}{
+		{"a", "a", true},
+		{"a", "b", false},
+		{`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, true},
+		{`{"a": 1}`, `{"a": 2}`, false},
+		{`[1, 2]`, `[2, 1]`, false},
+		{"not json", "not  json", false},
+		{[]string{"a"}, []string{"a"}, true},
+		{float64(1), "1", false},
+	}
+	for _, tt := range tests {
+		if got := equal(tt.state, tt.remote); got != tt.want {
+			t.Errorf("equal(%v, %v) = %v, want %v", tt.state, tt.remote, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package drift compares the PrivX objects recorded in Terraform state with
+// the objects in a live PrivX deployment.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Managed is a PrivX object recorded in Terraform state.
+type Managed struct {
+	Type    string
+	ID      string
+	Address string
+	// Attributes are the resource attributes from state. They are nil for
+	// objects managed through a set resource, such as hosts of a
+	// privx_host_set, whose attributes are not kept in state.
+	Attributes map[string]interface{}
+}
+
+// stateFile covers both a raw state file and the output of
+// terraform show -json.
+type stateFile struct {
+	Resources []stateResource `json:"resources"`
+	Values    *struct {
+		RootModule showModule `json:"root_module"`
+	} `json:"values"`
+}
+
+type stateResource struct {
+	Module    string `json:"module"`
+	Mode      string `json:"mode"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Instances []struct {
+		IndexKey   interface{}            `json:"index_key"`
+		Attributes map[string]interface{} `json:"attributes"`
+	} `json:"instances"`
+}
+
+type showModule struct {
+	Resources []struct {
+		Address string                 `json:"address"`
+		Mode    string                 `json:"mode"`
+		Type    string                 `json:"type"`
+		Values  map[string]interface{} `json:"values"`
+	} `json:"resources"`
+	ChildModules []showModule `json:"child_modules"`
+}
+
+// ReadState returns the managed PrivX objects of a state file or of
+// terraform show -json output.
+func ReadState(r io.Reader) ([]Managed, error) {
+	var state stateFile
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("unable to parse state: %w", err)
+	}
+
+	var managed []Managed
+	if state.Values != nil {
+		managed = showResources(state.Values.RootModule, managed)
+	}
+	for _, res := range state.Resources {
+		if res.Mode != "managed" {
+			continue
+		}
+		address := res.Type + "." + res.Name
+		if res.Module != "" {
+			address = res.Module + "." + address
+		}
+		for _, instance := range res.Instances {
+			managed = appendManaged(managed, res.Type, address+indexSuffix(instance.IndexKey), instance.Attributes)
+		}
+	}
+	return managed, nil
+}
+
+func showResources(module showModule, managed []Managed) []Managed {
+	for _, res := range module.Resources {
+		if res.Mode != "managed" {
+			continue
+		}
+		managed = appendManaged(managed, res.Type, res.Address, res.Values)
+	}
+	for _, child := range module.ChildModules {
+		managed = showResources(child, managed)
+	}
+	return managed
+}
+
+// appendManaged adds the objects a resource manages. Hosts of a
+// privx_host_set are added as privx_host objects.
+func appendManaged(managed []Managed, resourceType, address string, attributes map[string]interface{}) []Managed {
+	if resourceType == "privx_host_set" {
+		hostIDs, _ := attributes["host_ids"].(map[string]interface{})
+		for key, id := range hostIDs {
+			if id, ok := id.(string); ok && id != "" {
+				managed = append(managed, Managed{
+					Type:    "privx_host",
+					ID:      id,
+					Address: fmt.Sprintf("%s.hosts[%q]", address, key),
+				})
+			}
+		}
+		return managed
+	}
+
+	if !slices.Contains(Types, resourceType) {
+		return managed
+	}
+	id, _ := attributes["id"].(string)
+	if id == "" {
+		return managed
+	}
+	return append(managed, Managed{
+		Type:       resourceType,
+		ID:         id,
+		Address:    address,
+		Attributes: attributes,
+	})
+}
+
+func indexSuffix(key interface{}) string {
+	switch k := key.(type) {
+	case string:
+		return fmt.Sprintf("[%q]", k)
+	case float64:
+		return fmt.Sprintf("[%d]", int64(k))
+	}
+	return ""
+}
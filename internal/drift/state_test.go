@@ -0,0 +1,149 @@
+package drift
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestReadState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  []Managed
+	}{
+		{
+			name: "state file",
+			state: `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "privx_role",
+      "name": "admins",
+      "instances": [{"attributes": {"id": "r1", "name": "admins"}}]
+    },
+    {
+      "module": "module.web",
+      "mode": "managed",
+      "type": "privx_host",
+      "name": "web",
+      "instances": [
+        {"index_key": 0, "attributes": {"id": "h1"}},
+        {"index_key": "b", "attributes": {"id": "h2"}}
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "privx_role",
+      "name": "lookup",
+      "instances": [{"attributes": {"id": "r2"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "privx_license",
+      "name": "license",
+      "instances": [{"attributes": {"id": "privx-license"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "privx_role",
+      "name": "tainted",
+      "instances": [{"attributes": {"id": ""}}]
+    },
+    {
+      "mode": "managed",
+      "type": "privx_host_set",
+      "name": "fleet",
+      "instances": [{"attributes": {"id": "fleet", "host_ids": {"a": "h3", "b": ""}}}]
+    }
+  ]
+}`,
+			want: []Managed{
+				{Type: "privx_role", ID: "r1", Address: "privx_role.admins", Attributes: map[string]interface{}{"id": "r1", "name": "admins"}},
+				{Type: "privx_host", ID: "h1", Address: "module.web.privx_host.web[0]", Attributes: map[string]interface{}{"id": "h1"}},
+				{Type: "privx_host", ID: "h2", Address: `module.web.privx_host.web["b"]`, Attributes: map[string]interface{}{"id": "h2"}},
+				{Type: "privx_host", ID: "h3", Address: `privx_host_set.fleet.hosts["a"]`},
+			},
+		},
+		{
+			name: "terraform show",
+			state: `{
+  "format_version": "1.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {"address": "privx_access_group.default", "mode": "managed", "type": "privx_access_group", "values": {"id": "a1"}},
+        {"address": "data.privx_role.lookup", "mode": "data", "type": "privx_role", "values": {"id": "r2"}}
+      ],
+      "child_modules": [
+        {
+          "resources": [
+            {"address": "module.vault.privx_secret.db[\"x\"]", "mode": "managed", "type": "privx_secret", "values": {"id": "db"}}
+          ]
+        }
+      ]
+    }
+  }
+}`,
+			want: []Managed{
+				{Type: "privx_access_group", ID: "a1", Address: "privx_access_group.default", Attributes: map[string]interface{}{"id": "a1"}},
+				{Type: "privx_secret", ID: "db", Address: `module.vault.privx_secret.db["x"]`, Attributes: map[string]interface{}{"id": "db"}},
+			},
+		},
+		{
+			name:  "empty",
+			state: `{"version": 4, "resources": []}`,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadState(strings.NewReader(tt.state))
+			if err != nil {
+				t.Fatalf("ReadState: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadStateHostSet(t *testing.T) {
+	state := `{"resources": [{
+  "mode": "managed",
+  "type": "privx_host_set",
+  "name": "fleet",
+  "instances": [{"attributes": {"host_ids": {"a": "h1", "b": "h2", "c": "h3"}}}]
+}]}`
+
+	managed, err := ReadState(strings.NewReader(state))
+	if err != nil {
+		t.Fatalf("ReadState: %v", err)
+	}
+
+	var addresses []string
+	for _, m := range managed {
+		if m.Type != "privx_host" || m.Attributes != nil {
+			t.Errorf("got %+v, want a privx_host without attributes", m)
+		}
+		addresses = append(addresses, m.Address+"="+m.ID)
+	}
+	sort.Strings(addresses)
+	want := []string{
+		`privx_host_set.fleet.hosts["a"]=h1`,
+		`privx_host_set.fleet.hosts["b"]=h2`,
+		`privx_host_set.fleet.hosts["c"]=h3`,
+	}
+	if !reflect.DeepEqual(addresses, want) {
+		t.Errorf("got %v, want %v", addresses, want)
+	}
+}
+
+func TestReadStateInvalid(t *testing.T) {
+	if _, err := ReadState(strings.NewReader("not json")); err == nil {
+		t.Error("ReadState accepted invalid JSON")
+	}
+}
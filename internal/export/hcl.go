@@ -0,0 +1,157 @@
+package export
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// WriteHCL writes an import block and a resource block for every object.
+// References to objects that are exported too are written as references to
+// their resources so that Terraform orders the resources correctly.
+func WriteHCL(w io.Writer, objects []Object) error {
+	objects = sortObjects(objects)
+	names := resourceNames(objects)
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for i, o := range objects {
+		if i > 0 {
+			body.AppendNewline()
+		}
+		name := names[Ref{Type: o.Type, ID: o.ID}]
+
+		imp := body.AppendNewBlock("import", nil).Body()
+		imp.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: o.Type},
+			hcl.TraverseAttr{Name: name},
+		})
+		imp.SetAttributeValue("id", cty.StringVal(o.ID))
+		body.AppendNewline()
+
+		resource := body.AppendNewBlock("resource", []string{o.Type, name}).Body()
+		writeFields(resource, o.Fields, names)
+	}
+
+	_, err := f.WriteTo(w)
+	return err
+}
+
+// sortObjects orders objects by resource type, in the order of Types, and
+// then by name.
+func sortObjects(objects []Object) []Object {
+	order := make(map[string]int, len(Types))
+	for i, t := range Types {
+		order[t] = i
+	}
+
+	sorted := append([]Object(nil), objects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return order[sorted[i].Type] < order[sorted[j].Type]
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// resourceNames derives a unique resource name for every object from its
+// PrivX name.
+func resourceNames(objects []Object) map[Ref]string {
+	names := make(map[Ref]string, len(objects))
+	used := make(map[string]bool, len(objects))
+	for _, o := range objects {
+		base := resourceName(o.Name)
+		name := base
+		for n := 2; used[o.Type+"."+name]; n++ {
+			name = base + "_" + strconv.Itoa(n)
+		}
+		used[o.Type+"."+name] = true
+		names[Ref{Type: o.Type, ID: o.ID}] = name
+	}
+	return names
+}
+
+// resourceName turns a PrivX name into a valid Terraform identifier.
+func resourceName(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	out := strings.TrimSuffix(b.String(), "_")
+	if out == "" {
+		return "unnamed"
+	}
+	if out[0] >= '0' && out[0] <= '9' {
+		return "r_" + out
+	}
+	return out
+}
+
+func writeFields(body *hclwrite.Body, fields []Field, names map[Ref]string) {
+	for _, f := range fields {
+		if blocks, ok := f.Value.(Blocks); ok {
+			for _, nested := range blocks {
+				writeFields(body.AppendNewBlock(f.Name, nil).Body(), nested, names)
+			}
+			continue
+		}
+		body.SetAttributeRaw(f.Name, valueTokens(f.Value, names))
+	}
+}
+
+func valueTokens(value interface{}, names map[Ref]string) hclwrite.Tokens {
+	switch v := value.(type) {
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case int64:
+		return hclwrite.TokensForValue(cty.NumberIntVal(v))
+	case []string:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, s := range v {
+			elems = append(elems, hclwrite.TokensForValue(cty.StringVal(s)))
+		}
+		return hclwrite.TokensForTuple(elems)
+	case Ref:
+		name, ok := names[v]
+		if !ok {
+			return hclwrite.TokensForValue(cty.StringVal(v.ID))
+		}
+		return hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: v.Type},
+			hcl.TraverseAttr{Name: name},
+			hcl.TraverseAttr{Name: "id"},
+		})
+	case Nested:
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(v))
+		for _, f := range v {
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForIdentifier(f.Name),
+				Value: valueTokens(f.Value, names),
+			})
+		}
+		return hclwrite.TokensForObject(attrs)
+	case []Nested:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, nested := range v {
+			elems = append(elems, valueTokens(nested, names))
+		}
+		return hclwrite.TokensForTuple(elems)
+	}
+	return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+}
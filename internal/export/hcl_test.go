@@ -0,0 +1,169 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"web", "web"},
+		{"Web Servers", "web_servers"},
+		{"  prod -- db  ", "prod_db"},
+		{"ops/admins (EU)", "ops_admins_eu"},
+		{"2nd-line", "r_2nd_line"},
+		{"Ääkkönen", "kk_nen"},
+		{"", "unnamed"},
+		{"---", "unnamed"},
+	}
+	for _, tt := range tests {
+		if got := resourceName(tt.name); got != tt.want {
+			t.Errorf("resourceName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResourceNames(t *testing.T) {
+	objects := []Object{
+		{Type: "privx_role", ID: "r1", Name: "Admins"},
+		{Type: "privx_role", ID: "r2", Name: "admins"},
+		{Type: "privx_role", ID: "r3", Name: "ADMINS!"},
+		{Type: "privx_access_group", ID: "a1", Name: "Admins"},
+		{Type: "privx_host", ID: "h1", Name: ""},
+		{Type: "privx_host", ID: "h2", Name: "?"},
+	}
+	want := map[Ref]string{
+		{Type: "privx_role", ID: "r1"}:         "admins",
+		{Type: "privx_role", ID: "r2"}:         "admins_2",
+		{Type: "privx_role", ID: "r3"}:         "admins_3",
+		{Type: "privx_access_group", ID: "a1"}: "admins",
+		{Type: "privx_host", ID: "h1"}:         "unnamed",
+		{Type: "privx_host", ID: "h2"}:         "unnamed_2",
+	}
+
+	got := resourceNames(objects)
+	if len(got) != len(want) {
+		t.Errorf("got %d names, want %d: %v", len(got), len(want), got)
+	}
+	for ref, name := range want {
+		if got[ref] != name {
+			t.Errorf("name of %v = %q, want %q", ref, got[ref], name)
+		}
+	}
+}
+
+func TestWriteHCL(t *testing.T) {
+	objects := []Object{
+		{
+			Type: "privx_role",
+			ID:   "r1",
+			Name: "Web Admins",
+			Fields: []Field{
+				{Name: "name", Value: "Web Admins"},
+				{Name: "permit_agent", Value: true},
+				{Name: "access_group_id", Value: Ref{Type: "privx_access_group", ID: "a1"}},
+				{Name: "permissions", Value: []string{"hosts-view", "roles-view"}},
+				{Name: "source_id", Value: Ref{Type: "privx_source", ID: "not-exported"}},
+			},
+		},
+		{
+			Type: "privx_access_group",
+			ID:   "a1",
+			Name: "Default",
+			Fields: []Field{
+				{Name: "name", Value: "Default"},
+			},
+		},
+		{
+			Type: "privx_host",
+			ID:   "h1",
+			Name: "db",
+			Fields: []Field{
+				{Name: "common_name", Value: "db"},
+				{Name: "services", Value: []Nested{
+					{{Name: "service", Value: "SSH"}, {Name: "port", Value: int64(22)}},
+				}},
+				{Name: "principal", Value: Blocks{
+					{{Name: "principal", Value: "root"}},
+					{{Name: "principal", Value: "ops"}},
+				}},
+			},
+		},
+	}
+
+	want := `import {
+  to = privx_access_group.default
+  id = "a1"
+}
+
+resource "privx_access_group" "default" {
+  name = "Default"
+}
+
+import {
+  to = privx_role.web_admins
+  id = "r1"
+}
+
+resource "privx_role" "web_admins" {
+  name            = "Web Admins"
+  permit_agent    = true
+  access_group_id = privx_access_group.default.id
+  permissions     = ["hosts-view", "roles-view"]
+  source_id       = "not-exported"
+}
+
+import {
+  to = privx_host.db
+  id = "h1"
+}
+
+resource "privx_host" "db" {
+  common_name = "db"
+  services = [{
+    service = "SSH"
+    port    = 22
+  }]
+  principal {
+    principal = "root"
+  }
+  principal {
+    principal = "ops"
+  }
+}
+`
+
+	var b strings.Builder
+	if err := WriteHCL(&b, objects); err != nil {
+		t.Fatalf("WriteHCL: %v", err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("WriteHCL wrote:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestObjectField(t *testing.T) {
+	var o Object
+	o.field("empty_string", "")
+	o.field("empty_list", []string{})
+	o.field("empty_nested", []Nested{})
+	o.field("empty_blocks", Blocks{})
+	o.field("empty_ref", Ref{Type: "privx_role"})
+	o.field("name", "web")
+	o.field("enabled", false)
+	o.field("port", int64(0))
+
+	var names []string
+	for _, f := range o.Fields {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "name,enabled,port" {
+		t.Errorf("got fields %s, want name,enabled,port", got)
+	}
+	if o.Get("name") != "web" || o.Get("missing") != nil {
+		t.Errorf("Get returned %v and %v", o.Get("name"), o.Get("missing"))
+	}
+}
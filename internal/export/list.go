@@ -0,0 +1,411 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/apiproxy"
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/networkaccessmanager"
+	"github.com/SSHcom/privx-sdk-go/v2/api/response"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/workflow"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+)
+
+// pageSize is the page size used when listing objects.
+const pageSize = 100
+
+// Types are the resource types that can be exported, in output order.
+var Types = []string{
+	"privx_access_group",
+	"privx_role",
+	"privx_source",
+	"privx_whitelist",
+	"privx_workflow",
+	"privx_host",
+	"privx_network_target",
+	"privx_api_target",
+	"privx_extender",
+}
+
+// Lister lists PrivX objects with the same SDK clients the provider uses.
+type Lister struct {
+	authorizer *authorizer.Authorizer
+	roles      *rolestore.RoleStore
+	hosts      *hoststore.HostStore
+	workflows  *workflow.WorkflowEngine
+	network    *networkaccessmanager.NetworkAccessManager
+	apiProxy   *apiproxy.ApiProxy
+	users      *userstore.UserStore
+}
+
+// NewLister returns a lister that uses connector.
+func NewLister(connector restapi.Connector) *Lister {
+	return &Lister{
+		authorizer: authorizer.New(connector),
+		roles:      rolestore.New(connector),
+		hosts:      hoststore.New(connector),
+		workflows:  workflow.New(connector),
+		network:    networkaccessmanager.New(connector),
+		apiProxy:   apiproxy.New(connector),
+		users:      userstore.New(connector),
+	}
+}
+
+// List returns the objects of the given resource types.
+func (l *Lister) List(resourceTypes []string) ([]Object, error) {
+	var objects []Object
+	for _, resourceType := range resourceTypes {
+		list, ok := l.listers()[resourceType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource type %q", resourceType)
+		}
+		found, err := list()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %s objects: %w", resourceType, err)
+		}
+		objects = append(objects, found...)
+	}
+	return objects, nil
+}
+
+func (l *Lister) listers() map[string]func() ([]Object, error) {
+	return map[string]func() ([]Object, error){
+		"privx_access_group":   l.accessGroups,
+		"privx_role":           l.roleObjects,
+		"privx_source":         l.sources,
+		"privx_whitelist":      l.whitelists,
+		"privx_workflow":       l.workflowObjects,
+		"privx_host":           l.hostObjects,
+		"privx_network_target": l.networkTargets,
+		"privx_api_target":     l.apiTargets,
+		"privx_extender":       l.extenders,
+	}
+}
+
+// listAll reads every page of a paged list call.
+func listAll[T any](list func(opts ...filters.Option) (*response.ResultSet[T], error)) ([]T, error) {
+	var items []T
+	for offset := 0; ; offset += pageSize {
+		page, err := list(filters.Paging(offset, pageSize))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if len(page.Items) < pageSize || len(items) >= page.Count {
+			return items, nil
+		}
+	}
+}
+
+func (l *Lister) accessGroups() ([]Object, error) {
+	groups, err := listAll(l.authorizer.GetAccessGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, g := range groups {
+		o := Object{Type: "privx_access_group", ID: g.ID, Name: g.Name}
+		o.field("name", g.Name)
+		o.field("comment", g.Comment)
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (l *Lister) roleObjects() ([]Object, error) {
+	roles, err := listAll(l.roles.GetRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, r := range roles {
+		rules, err := json.Marshal(r.SourceRules)
+		if err != nil {
+			return nil, err
+		}
+
+		o := Object{Type: "privx_role", ID: r.ID, Name: r.Name}
+		o.field("name", r.Name)
+		o.field("access_group_id", Ref{Type: "privx_access_group", ID: r.AccessGroupID})
+		o.field("comment", r.Comment)
+		o.field("permissions", r.Permissions)
+		o.field("permit_agent", r.PermitAgent)
+		o.field("source_rules", string(rules))
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+// sources lists the OIDC sources, the only kind privx_source manages.
+// Client secrets are not returned by PrivX and must be added by hand.
+func (l *Lister) sources() ([]Object, error) {
+	sources, err := l.roles.GetSources()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, s := range sources.Items {
+		if s.Connection.Type != "OIDC" {
+			continue
+		}
+
+		connection := &Object{}
+		connection.field("enabled", s.Connection.OIDCEnabled)
+		connection.field("address", s.Connection.Address)
+		connection.field("issuer", s.Connection.OIDCIssuer)
+		connection.field("client_id", s.Connection.OIDCClientID)
+		connection.field("button_title", s.Connection.OIDCButtonTitle)
+		connection.field("tags_attribute_name", s.Connection.OIDCTagsAttributeName)
+		connection.field("additional_scopes_secret", s.Connection.OIDCAdditionalScopes)
+
+		o := Object{Type: "privx_source", ID: s.ID, Name: s.Name}
+		o.field("name", s.Name)
+		o.field("comment", s.Comment)
+		o.field("enabled", s.Enabled)
+		o.field("ttl", int64(s.TTL))
+		o.field("tags", s.Tags)
+		o.field("username_pattern", s.UsernamePattern)
+		o.field("oidc_connection", Nested(connection.Fields))
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (l *Lister) whitelists() ([]Object, error) {
+	whitelists, err := listAll(l.hosts.GetWhitelists)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, w := range whitelists {
+		o := Object{Type: "privx_whitelist", ID: w.ID, Name: w.Name}
+		o.field("name", w.Name)
+		o.field("comment", w.Comment)
+		o.field("type", w.Type)
+		o.field("whitelist_patterns", w.WhiteListPatterns)
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (l *Lister) workflowObjects() ([]Object, error) {
+	workflows, err := listAll(l.workflows.GetWorkflows)
+	if err != nil {
+		return nil, err
+	}
+
+	roleRefs := func(roles []workflow.WorkflowRole) []Nested {
+		var out []Nested
+		for _, r := range roles {
+			out = append(out, Nested{{Name: "id", Value: Ref{Type: "privx_role", ID: r.ID}}})
+		}
+		return out
+	}
+
+	var objects []Object
+	for _, w := range workflows {
+		var steps []Nested
+		for _, s := range w.Steps {
+			var approvers []Nested
+			for _, a := range s.Approvers {
+				approvers = append(approvers, Nested{{Name: "role", Value: Nested{{Name: "id", Value: Ref{Type: "privx_role", ID: a.Role.ID}}}}})
+			}
+			steps = append(steps, Nested{
+				{Name: "name", Value: s.Name},
+				{Name: "match", Value: s.Match},
+				{Name: "approvers", Value: approvers},
+			})
+		}
+
+		o := Object{Type: "privx_workflow", ID: w.ID, Name: w.Name}
+		o.field("name", w.Name)
+		o.field("comment", w.Comment)
+		o.field("action", w.Action)
+		o.field("grant_types", w.GrantTypes)
+		o.field("requires_justification", w.RequiresJustification)
+		o.field("can_bypass_revoke_workflow", w.CanBypassRevokeWF)
+		o.field("max_active_requests", w.MaxActiveRequests)
+		o.field("max_time_restricted_duration", w.MaxTimeRestrictedDuration)
+		o.field("max_floating_duration", w.MaxFloatingDuration)
+		o.field("requester_roles", roleRefs(w.RequestorRoles))
+		o.field("target_roles", roleRefs(w.TargetRoles))
+		o.field("steps", steps)
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (l *Lister) hostObjects() ([]Object, error) {
+	hosts, err := listAll(l.hosts.GetHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, h := range hosts {
+		var services []Nested
+		for _, s := range h.Services {
+			services = append(services, Nested{
+				{Name: "service", Value: s.Service},
+				{Name: "address", Value: s.Address},
+				{Name: "port", Value: int64(s.Port)},
+			})
+		}
+
+		var principals []Nested
+		for _, p := range h.Principals {
+			var roles []Nested
+			for _, r := range p.Roles {
+				roles = append(roles, Nested{{Name: "id", Value: Ref{Type: "privx_role", ID: r.ID}}})
+			}
+			principal := Object{Fields: []Field{{Name: "principal", Value: p.Principal}}}
+			principal.field("roles", roles)
+			principals = append(principals, Nested(principal.Fields))
+		}
+
+		o := Object{Type: "privx_host", ID: h.ID, Name: h.CommonName}
+		o.field("common_name", h.CommonName)
+		o.field("access_group_id", Ref{Type: "privx_access_group", ID: h.AccessGroupID})
+		// addresses is required, so it is written even when empty.
+		o.Fields = append(o.Fields, Field{Name: "addresses", Value: append([]string{}, h.Addresses...)})
+		o.field("external_id", h.ExternalID)
+		o.field("comment", h.Comment)
+		o.field("tags", h.Tags)
+		o.field("services", services)
+		o.field("principals", principals)
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (l *Lister) networkTargets() ([]Object, error) {
+	targets, err := listAll(l.network.GetNetworkTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, t := range targets {
+		var roles Blocks
+		for _, r := range t.Roles {
+			roles = append(roles, Nested{{Name: "id", Value: Ref{Type: "privx_role", ID: r.ID}}})
+		}
+
+		var dst Blocks
+		for _, d := range t.Dst {
+			block := Object{}
+			block.field("ip_start", d.Sel.IP.Start)
+			block.field("ip_end", d.Sel.IP.End)
+			block.field("protocol", d.Sel.Protocol)
+			if d.Sel.Port != nil {
+				block.field("port_start", int64(d.Sel.Port.Start))
+				block.field("port_end", int64(d.Sel.Port.End))
+			}
+			if d.NAT != nil {
+				block.field("nat_addr", d.NAT.Addr)
+				if d.NAT.Port != 0 {
+					block.field("nat_port", int64(d.NAT.Port))
+				}
+			}
+			dst = append(dst, Nested(block.Fields))
+		}
+
+		o := Object{Type: "privx_network_target", ID: t.ID, Name: t.Name}
+		o.field("name", t.Name)
+		o.field("comment", t.Comment)
+		o.field("user_instructions", t.UserInstructions)
+		o.field("integration_type", t.IntegrationType)
+		o.field("disabled", strings.EqualFold(t.Disabled, "true"))
+		o.field("src_nat", t.SrcNAT)
+		o.field("exclusive_access", t.ExclusiveAccess)
+		o.field("tags", t.Tags)
+		o.field("roles", roles)
+		o.field("dst", dst)
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+// apiTargets lists API targets. Target credentials are not returned by
+// PrivX; only their type is exported.
+func (l *Lister) apiTargets() ([]Object, error) {
+	targets, err := listAll(l.apiProxy.GetApiTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := func(in []apiproxy.ApiTargetEndpoint) []Nested {
+		var out []Nested
+		for _, e := range in {
+			endpoint := Object{}
+			endpoint.field("host", e.Host)
+			endpoint.field("protocols", e.Protocols)
+			endpoint.field("methods", e.Methods)
+			endpoint.field("paths", e.Paths)
+			endpoint.field("allow_unauthenticated", e.AllowUnauthenticated)
+			endpoint.field("nat_target_host", e.NATTargetHost)
+			out = append(out, Nested(endpoint.Fields))
+		}
+		return out
+	}
+
+	var objects []Object
+	for _, t := range targets {
+		var roles []Nested
+		for _, r := range t.Roles {
+			roles = append(roles, Nested{{Name: "id", Value: Ref{Type: "privx_role", ID: r.ID}}})
+		}
+
+		o := Object{Type: "privx_api_target", ID: t.ID, Name: t.Name}
+		o.field("name", t.Name)
+		o.field("comment", t.Comment)
+		o.field("access_group_id", Ref{Type: "privx_access_group", ID: t.AccessGroupID})
+		o.field("tags", t.Tags)
+		o.field("roles", roles)
+		o.field("authorized_endpoints", endpoints(t.AuthorizedEndpoints))
+		o.field("unauthorized_endpoints", endpoints(t.UnauthorizedEndpoints))
+		o.field("tls_insecure_skip_verify", t.TLSInsecureSkipVerify)
+		o.field("audit_enabled", t.AuditEnabled)
+		o.field("target_credential", Nested{{Name: "type", Value: t.TargetCredential.Type}})
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+func (l *Lister) extenders() ([]Object, error) {
+	clients, err := l.users.GetTrustedClients()
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	for _, c := range clients.Items {
+		if c.Type != "EXTENDER" {
+			continue
+		}
+
+		o := Object{Type: "privx_extender", ID: c.ID, Name: c.Name}
+		o.field("name", c.Name)
+		o.field("access_group_id", Ref{Type: "privx_access_group", ID: c.AccessGroupID})
+		o.field("extender_address", c.ExtenderAddress)
+		o.field("subnets", c.Subnets)
+		o.field("routing_prefix", c.RoutingPrefix)
+		o.field("web_proxy_address", c.WebProxyAddress)
+		if port, err := strconv.ParseInt(c.WebProxyPort, 10, 64); err == nil {
+			o.field("web_proxy_port", port)
+		}
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
@@ -0,0 +1,76 @@
+// Package export lists PrivX objects through the SDK and renders them as
+// Terraform configuration with import blocks.
+package export
+
+// Object is a PrivX object described with the attributes of the provider
+// resource that manages it.
+type Object struct {
+	// Type is the Terraform resource type, e.g. privx_role.
+	Type string
+	// ID is the PrivX ID, which is also the Terraform import ID.
+	ID string
+	// Name is the human readable name the resource name is derived from.
+	Name string
+	// Fields are the resource attributes in output order.
+	Fields []Field
+}
+
+// Field is one attribute of an object or of a nested object.
+//
+// A value is a string, bool, int64, []string, Ref, Nested, []Nested or
+// Blocks.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// Ref refers to another PrivX object by ID. It is rendered as the id
+// attribute of that object's resource when the object is exported too.
+type Ref struct {
+	Type string
+	ID   string
+}
+
+// Nested is a nested attribute object.
+type Nested []Field
+
+// Blocks are nested blocks, rendered as one block per element.
+type Blocks []Nested
+
+// Get returns the value of the named field, or nil.
+func (o Object) Get(name string) interface{} {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// field appends a field, leaving out empty strings and empty lists so that
+// the generated configuration only sets what differs from the defaults.
+func (o *Object) field(name string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return
+		}
+	case []string:
+		if len(v) == 0 {
+			return
+		}
+	case []Nested:
+		if len(v) == 0 {
+			return
+		}
+	case Blocks:
+		if len(v) == 0 {
+			return
+		}
+	case Ref:
+		if v.ID == "" {
+			return
+		}
+	}
+	o.Fields = append(o.Fields, Field{Name: name, Value: value})
+}
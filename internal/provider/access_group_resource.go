@@ -6,7 +6,9 @@ import (
 	"terraform-provider-privx/internal/utils"
 
 	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
 	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AccessGroupResource{}
 var _ resource.ResourceWithImportState = &AccessGroupResource{}
+var _ resource.ResourceWithModifyPlan = &AccessGroupResource{}
 
 func NewAccessGroupResource() resource.Resource {
 	return &AccessGroupResource{}
@@ -27,14 +30,22 @@ func NewAccessGroupResource() resource.Resource {
 
 // AccessGroupResource defines the resource implementation.
 type AccessGroupResource struct {
-	client *authorizer.Authorizer
+	client    *authorizer.Authorizer
+	connector restapi.Connector
 }
 
 // AccessGroup contains PrivX access group information.
 type AccessGroupResourceModel struct {
-	ID      types.String `tfsdk:"id"`
-	Name    types.String `tfsdk:"name"`
-	Comment types.String `tfsdk:"comment"`
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Comment             types.String `tfsdk:"comment"`
+	CAKeyType           types.String `tfsdk:"ca_key_type"`
+	CARotateTrigger     types.String `tfsdk:"ca_rotate_trigger"`
+	CAID                types.String `tfsdk:"ca_id"`
+	CAPublicKey         types.String `tfsdk:"ca_public_key"`
+	CAFingerprintSHA256 types.String `tfsdk:"ca_fingerprint_sha256"`
+	AdoptExisting       types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *AccessGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +64,7 @@ func (r *AccessGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "AccessGroup name",
 				Required:            true,
@@ -66,10 +78,70 @@ func (r *AccessGroupResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
 			},
+			"ca_key_type": schema.StringAttribute{
+				MarkdownDescription: "Key type of the access group CA, used when the group is created and when the CA is rotated. Defaults to the PrivX default key type.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_rotate_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value; changing it renews the access group CA key. The previous key stays valid until revoked in PrivX.",
+				Optional:            true,
+			},
+			"ca_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the primary CA key of the access group",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_public_key": schema.StringAttribute{
+				MarkdownDescription: "Public key of the primary access group CA in OpenSSH format, for host and user certificate trust anchors",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ca_fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 fingerprint of the primary access group CA public key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"adopt_existing": adoptExistingAttribute("name"),
 		},
 	}
 }
 
+// ModifyPlan marks the CA attributes unknown when the CA is about to be rotated.
+func (r *AccessGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state AccessGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !accessGroupCARotates(&plan, &state) {
+		return
+	}
+
+	plan.CAID = types.StringUnknown()
+	plan.CAPublicKey = types.StringUnknown()
+	plan.CAFingerprintSHA256 = types.StringUnknown()
+	if plan.CAKeyType.Equal(state.CAKeyType) {
+		plan.CAKeyType = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r *AccessGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -90,6 +162,7 @@ func (r *AccessGroupResource) Configure(ctx context.Context, req resource.Config
 	})
 
 	r.client = authorizer.New(*connector)
+	r.connector = *connector
 }
 
 func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -107,12 +180,25 @@ func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateReq
 	})
 
 	accessGroup := authorizer.AccessGroup{
-		Name:    data.Name.ValueString(),
-		Comment: data.Comment.ValueString(),
+		Name:      data.Name.ValueString(),
+		Comment:   data.Comment.ValueString(),
+		CAKeyType: data.CAKeyType.ValueString(),
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("authorizer.AccessGroup model used: %+v", accessGroup))
 
+	if data.AdoptExisting.ValueBool() {
+		existing, err := findAccessGroupByName(r.client, accessGroup.Name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up existing access group %q, got error: %s", accessGroup.Name, err))
+			return
+		}
+		if existing != nil {
+			r.adopt(ctx, data, existing, resp)
+			return
+		}
+	}
+
 	accessGroupID, err := r.client.CreateAccessGroup(&accessGroup)
 
 	if err != nil {
@@ -128,6 +214,16 @@ func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateReq
 	// and set any unknown attribute values.
 	data.ID = types.StringValue(accessGroupID.ID)
 
+	created, err := r.client.GetAccessGroup(accessGroupID.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access group, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(r.readCA(data, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Debug(ctx, "created access group resource")
@@ -136,6 +232,40 @@ func (r *AccessGroupResource) Create(ctx context.Context, req resource.CreateReq
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// adopt takes over an existing access group on create. The CA is renewed
+// when a different ca_key_type is configured.
+func (r *AccessGroupResource) adopt(ctx context.Context, data *AccessGroupResourceModel, existing *authorizer.AccessGroup, resp *resource.CreateResponse) {
+	existing.Name = data.Name.ValueString()
+	existing.Comment = data.Comment.ValueString()
+	if err := r.client.UpdateAccessGroup(existing.ID, existing); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update adopted access group %s, got error: %s", existing.ID, err))
+		return
+	}
+
+	keyType := data.CAKeyType.ValueString()
+	if !data.CAKeyType.IsUnknown() && keyType != "" && existing.CAKeyType != "" && keyType != existing.CAKeyType {
+		renewal := &authorizer.AccessGroupCARenewal{CAKeyType: keyType}
+		if _, err := r.client.RenewAccessGroupCAKey(existing.ID, renewal); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate adopted access group CA, got error: %s", err))
+			return
+		}
+	}
+	warnAdopted(ctx, &resp.Diagnostics, "privx_access_group", existing.Name, existing.ID)
+
+	data.ID = types.StringValue(existing.ID)
+	adopted, err := r.client.GetAccessGroup(existing.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access group, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(r.readCA(data, adopted)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
 func (r *AccessGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data *AccessGroupResourceModel
 
@@ -156,6 +286,16 @@ func (r *AccessGroupResource) Read(ctx context.Context, req resource.ReadRequest
 
 	data.Name = types.StringValue(accessGroup.Name)
 	data.Comment = types.StringValue(accessGroup.Comment)
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+	resp.Diagnostics.Append(r.readCA(data, accessGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -191,6 +331,38 @@ func (r *AccessGroupResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	var state *AccessGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if accessGroupCARotates(data, state) {
+		renewal := &authorizer.AccessGroupCARenewal{CAKeyType: data.CAKeyType.ValueString()}
+		if data.CAKeyType.IsUnknown() {
+			renewal.CAKeyType = state.CAKeyType.ValueString()
+		}
+
+		tflog.Info(ctx, "Rotating access group CA", map[string]interface{}{
+			"id":       data.ID.ValueString(),
+			"key_type": renewal.CAKeyType,
+		})
+		if _, err := r.client.RenewAccessGroupCAKey(data.ID.ValueString(), renewal); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate access group CA, got error: %s", err))
+			return
+		}
+	}
+
+	updated, err := r.client.GetAccessGroup(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access group, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(r.readCA(data, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -205,6 +377,10 @@ func (r *AccessGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	if !checkDeletionProtection(r.connector, data.DeletionProtection, "privx_access_group", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	err := r.client.DeleteAccessGroup(data.ID.ValueString())
 	if err != nil {
 		if utils.IsPrivxNotFound(err) {
@@ -219,3 +395,73 @@ func (r *AccessGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 func (r *AccessGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// accessGroupCARotates reports whether applying plan renews the CA key: the
+// rotation trigger changed or a different key type was configured.
+func accessGroupCARotates(plan, state *AccessGroupResourceModel) bool {
+	if !plan.CARotateTrigger.Equal(state.CARotateTrigger) {
+		return true
+	}
+	return !plan.CAKeyType.IsUnknown() && !plan.CAKeyType.IsNull() && !plan.CAKeyType.Equal(state.CAKeyType)
+}
+
+// readCA fills the CA attributes from the primary CA of the access group.
+// A primary CA the authorizer does not list is reported as a warning and
+// leaves the CA key attributes null.
+func (r *AccessGroupResource) readCA(data *AccessGroupResourceModel, group *authorizer.AccessGroup) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	caID := group.PrimaryCAID
+	if caID == "" {
+		caID = group.CAID
+	}
+
+	data.CAKeyType = types.StringValue(group.CAKeyType)
+	data.CAID = types.StringValue(caID)
+	data.CAPublicKey = types.StringNull()
+	data.CAFingerprintSHA256 = types.StringNull()
+
+	ca, err := findAccessGroupCA(r.client, group.ID, caID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read access group CA, got error: %s", err))
+		return diags
+	}
+	if ca == nil {
+		diags.AddWarning(
+			"Access group CA not found",
+			fmt.Sprintf("The authorizer does not list the primary CA %q of access group %s, so ca_public_key and ca_fingerprint_sha256 are not set.", caID, group.ID),
+		)
+		return diags
+	}
+
+	data.CAPublicKey = types.StringValue(ca.PublicKeyString)
+	data.CAFingerprintSHA256 = types.StringValue(ca.FingerPrintSHA256)
+	if info, err := parseSSHPublicKey(ca.PublicKeyString); err == nil {
+		data.CAFingerprintSHA256 = types.StringValue(info.FingerprintSHA256)
+	}
+	if data.CAKeyType.ValueString() == "" {
+		data.CAKeyType = types.StringValue(ca.Type)
+	}
+	return diags
+}
+
+// findAccessGroupCA returns the CA key caID of an access group, or nil when
+// the authorizer does not list it. Only the CAs of the access group are
+// requested.
+func findAccessGroupCA(client *authorizer.Authorizer, accessGroupID, caID string) (*authorizer.CA, error) {
+	if caID == "" {
+		return nil, nil
+	}
+
+	cas, err := client.GetCACertificates(filters.SetCustomParams("access_group_id", accessGroupID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, ca := range cas.Items {
+		if ca.ID == caID && (ca.AccessGroupID == "" || ca.AccessGroupID == accessGroupID) {
+			return &cas.Items[i], nil
+		}
+	}
+	return nil, nil
+}
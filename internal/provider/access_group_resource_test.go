@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAccessGroupCARotation(t *testing.T) {
+	name := "tf-acc-agca-" + acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_access_group.test"
+
+	cfg1 := testAccAccessGroupCAConfig(name, "1")
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccAccessGroupCAConfig(name, "2")
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	var firstCAID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "ca_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_public_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "ca_fingerprint_sha256"),
+					func(s *terraform.State) error {
+						firstCAID = s.RootModule().Resources[resourceName].Primary.Attributes["ca_id"]
+						return nil
+					},
+				),
+			},
+
+			// ROTATE
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						caID := s.RootModule().Resources[resourceName].Primary.Attributes["ca_id"]
+						if caID == firstCAID {
+							return fmt.Errorf("ca_id did not change after rotation: %s", caID)
+						}
+						return nil
+					},
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ca_rotate_trigger"},
+			},
+		},
+	})
+}
+
+func testAccAccessGroupCAConfig(name, trigger string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name              = %[1]q
+  comment           = "temp access group for CA rotation test"
+  ca_rotate_trigger = %[2]q
+}
+`, name, trigger)
+}
+
+func TestAccAccessGroupDeletionProtection(t *testing.T) {
+	name := "tf-acc-agdp-" + acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+
+	cfgProtected := testAccAccessGroupDeletionProtectionConfig(name, true)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfgProtected)
+
+	cfgRemoved := testAccProviderOnlyConfig
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfgRemoved)
+
+	cfgUnprotected := testAccAccessGroupDeletionProtectionConfig(name, false)
+	writeAccConfig(t, fmt.Sprintf("%s_step_3.tf", t.Name()), cfgUnprotected)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE protected
+			{
+				Config: cfgProtected,
+				Check:  resource.TestCheckResourceAttr("privx_access_group.test", "deletion_protection", "true"),
+			},
+
+			// DELETE refused
+			{
+				Config:      cfgRemoved,
+				ExpectError: regexp.MustCompile(`Deletion protection enabled`),
+			},
+
+			// UNPROTECT so that the group can be destroyed
+			{
+				Config: cfgUnprotected,
+				Check:  resource.TestCheckResourceAttr("privx_access_group.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func testAccAccessGroupDeletionProtectionConfig(name string, protected bool) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name                = %[1]q
+  comment             = "temp access group for deletion protection test"
+  deletion_protection = %[2]t
+}
+`, name, protected)
+}
+
+const testAccProviderOnlyConfig = `
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+`
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccessGroupsDataSource{}
+
+func NewAccessGroupsDataSource() datasource.DataSource {
+	return &AccessGroupsDataSource{}
+}
+
+// AccessGroupsDataSource defines the data source implementation.
+type AccessGroupsDataSource struct {
+	client *authorizer.Authorizer
+}
+
+// AccessGroupsDataSourceModel describes the data source data model.
+type AccessGroupsDataSourceModel struct {
+	ID           types.String            `tfsdk:"id"`
+	NameRegex    types.String            `tfsdk:"name_regex"`
+	Author       types.String            `tfsdk:"author"`
+	IDs          []types.String          `tfsdk:"ids"`
+	AccessGroups []AccessGroupsItemModel `tfsdk:"access_groups"`
+}
+
+// AccessGroupsItemModel is one access group.
+type AccessGroupsItemModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Comment   types.String `tfsdk:"comment"`
+	Default   types.Bool   `tfsdk:"default"`
+	CAID      types.String `tfsdk:"ca_id"`
+	CAKeyType types.String `tfsdk:"ca_key_type"`
+	Author    types.String `tfsdk:"author"`
+	Created   types.String `tfsdk:"created"`
+}
+
+func (d *AccessGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_groups"
+}
+
+func (d *AccessGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Access groups data source. Lists access groups sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"id":         computed("Data source ID"),
+			"name_regex": nameRegexAttribute("access groups"),
+			"author": schema.StringAttribute{
+				MarkdownDescription: "Only access groups created by this user ID",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching access groups",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"access_groups": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching access groups",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":      computed("Access group ID"),
+						"name":    computed("Access group name"),
+						"comment": computed("Access group comment"),
+						"default": schema.BoolAttribute{
+							MarkdownDescription: "Whether this is the default access group",
+							Computed:            true,
+						},
+						"ca_id":       computed("ID of the primary CA"),
+						"ca_key_type": computed("Key type of the CA"),
+						"author":      computed("ID of the user who created the access group"),
+						"created":     computed("Creation time"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccessGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating authorizer client", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = authorizer.New(*connector)
+}
+
+func (d *AccessGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccessGroupsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := regexp.MustCompile(data.NameRegex.ValueString())
+	author := data.Author.ValueString()
+
+	var accessGroups []authorizer.AccessGroup
+	for offset := 0; ; offset += listPageSize {
+		page, err := d.client.GetAccessGroups(filters.Paging(offset, listPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list access groups, got error: %s", err))
+			return
+		}
+
+		for _, ag := range page.Items {
+			if !nameRegex.MatchString(ag.Name) || (author != "" && ag.Author != author) {
+				continue
+			}
+			accessGroups = append(accessGroups, ag)
+		}
+
+		if len(page.Items) < listPageSize {
+			break
+		}
+	}
+
+	sort.Slice(accessGroups, func(i, j int) bool { return accessGroups[i].Name < accessGroups[j].Name })
+
+	data.IDs = []types.String{}
+	data.AccessGroups = []AccessGroupsItemModel{}
+	for _, ag := range accessGroups {
+		caID := ag.PrimaryCAID
+		if caID == "" {
+			caID = ag.CAID
+		}
+		data.IDs = append(data.IDs, types.StringValue(ag.ID))
+		data.AccessGroups = append(data.AccessGroups, AccessGroupsItemModel{
+			ID:        types.StringValue(ag.ID),
+			Name:      types.StringValue(ag.Name),
+			Comment:   types.StringValue(ag.Comment),
+			Default:   types.BoolValue(ag.Default),
+			CAID:      types.StringValue(caID),
+			CAKeyType: types.StringValue(ag.CAKeyType),
+			Author:    types.StringValue(ag.Author),
+			Created:   types.StringValue(ag.Created),
+		})
+	}
+
+	data.ID = types.StringValue(data.NameRegex.ValueString() + "/" + author)
+
+	tflog.Debug(ctx, "Storing access groups into the state", map[string]interface{}{
+		"count": len(data.AccessGroups),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
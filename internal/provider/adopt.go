@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// adoptExistingAttribute is the schema of the adopt_existing attribute of
+// resources whose Create can take over an existing object with the same
+// natural key.
+func adoptExistingAttribute(naturalKey string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("When an object with the same `%s` already exists, take it over on create and apply the configuration to it instead of failing. Only used on create", naturalKey),
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+}
+
+// warnAdopted reports that Create took over an existing object.
+func warnAdopted(ctx context.Context, diags *diag.Diagnostics, resourceType, naturalKey, id string) {
+	tflog.Warn(ctx, "Adopted existing object", map[string]interface{}{
+		"type":        resourceType,
+		"natural_key": naturalKey,
+		"id":          id,
+	})
+	diags.AddWarning(
+		"Adopted existing object",
+		fmt.Sprintf("%s %q already existed in PrivX with ID %s. It is now managed by Terraform and was updated to match the configuration.", resourceType, naturalKey, id),
+	)
+}
+
+// findHostByCommonName returns the host with the given common name, or nil
+// if there is none.
+func findHostByCommonName(client *hoststore.HostStore, commonName string) (*hoststore.Host, error) {
+	result, err := client.SearchHosts(&hoststore.HostSearch{CommonName: []string{commonName}})
+	if err != nil {
+		return nil, err
+	}
+
+	var found *hoststore.Host
+	for i := range result.Items {
+		if result.Items[i].CommonName != commonName {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one host found with common name %q", commonName)
+		}
+		found = &result.Items[i]
+	}
+	return found, nil
+}
+
+// findRoleByName returns the role with the given name, or nil if there is
+// none.
+func findRoleByName(client *rolestore.RoleStore, name string) (*rolestore.Role, error) {
+	for offset := 0; ; offset += listPageSize {
+		page, err := client.GetRoles(filters.Paging(offset, listPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Items {
+			if page.Items[i].Name == name {
+				return &page.Items[i], nil
+			}
+		}
+		if len(page.Items) < listPageSize {
+			return nil, nil
+		}
+	}
+}
+
+// findAccessGroupByName returns the access group with the given name, or
+// nil if there is none.
+func findAccessGroupByName(client *authorizer.Authorizer, name string) (*authorizer.AccessGroup, error) {
+	for offset := 0; ; offset += listPageSize {
+		page, err := client.GetAccessGroups(filters.Paging(offset, listPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Items {
+			if page.Items[i].Name == name {
+				return &page.Items[i], nil
+			}
+		}
+		if len(page.Items) < listPageSize {
+			return nil, nil
+		}
+	}
+}
@@ -27,17 +27,19 @@ func NewAPIClientResource() resource.Resource {
 
 // APIClientResource defines the resource implementation.
 type APIClientResource struct {
-	client *userstore.UserStore
+	client    *userstore.UserStore
+	connector restapi.Connector
 }
 
 // APIClientModel describes the resource data model.
 type APIClientModel struct {
-	ID                types.String    `tfsdk:"id"`
-	Name              types.String    `tfsdk:"name"`
-	Secret            types.String    `tfsdk:"secret"`
-	OauthClientId     types.String    `tfsdk:"oauth_client_id"`
-	OauthClientSecret types.String    `tfsdk:"oauth_client_secret"`
-	Roles             []RolesRefModel `tfsdk:"roles"`
+	ID                 types.String    `tfsdk:"id"`
+	Name               types.String    `tfsdk:"name"`
+	Secret             types.String    `tfsdk:"secret"`
+	OauthClientId      types.String    `tfsdk:"oauth_client_id"`
+	OauthClientSecret  types.String    `tfsdk:"oauth_client_secret"`
+	Roles              []RolesRefModel `tfsdk:"roles"`
+	DeletionProtection types.Bool      `tfsdk:"deletion_protection"`
 }
 
 func (r *APIClientResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,6 +59,7 @@ func (r *APIClientResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "name of the API client",
 				Required:            true,
@@ -122,6 +125,7 @@ func (r *APIClientResource) Configure(ctx context.Context, req resource.Configur
 	})
 
 	r.client = userstore.New(*connector)
+	r.connector = *connector
 }
 
 func (r *APIClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -266,6 +270,10 @@ func (r *APIClientResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 	data.Roles = roles
 
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -316,6 +324,11 @@ func (r *APIClientResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if !checkDeletionProtection(r.connector, data.DeletionProtection, "privx_api_client", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	if err := r.client.DeleteAPIClient(data.ID.ValueString()); err != nil {
 		if utils.IsPrivxNotFound(err) {
 			return
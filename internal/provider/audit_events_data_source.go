@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/monitor"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuditEventsDataSource{}
+
+const (
+	// auditEventsPageSize is the page size used when searching audit events.
+	auditEventsPageSize = 100
+
+	// auditEventsDefaultMaxResults caps the result when max_results is not set.
+	auditEventsDefaultMaxResults = 1000
+
+	// auditEventsDefaultMaxScanned caps the events read to filter by
+	// event_types when max_scanned is not set.
+	auditEventsDefaultMaxScanned = 10000
+)
+
+func NewAuditEventsDataSource() datasource.DataSource {
+	return &AuditEventsDataSource{}
+}
+
+// AuditEventsDataSource defines the data source implementation.
+type AuditEventsDataSource struct {
+	client *monitor.Monitor
+}
+
+// AuditEventsDataSourceModel describes the data source data model.
+type AuditEventsDataSourceModel struct {
+	ID            types.String          `tfsdk:"id"`
+	EventTypes    []types.String        `tfsdk:"event_types"`
+	StartTime     types.String          `tfsdk:"start_time"`
+	EndTime       types.String          `tfsdk:"end_time"`
+	UserID        types.String          `tfsdk:"user_id"`
+	HostID        types.String          `tfsdk:"host_id"`
+	ConnectionID  types.String          `tfsdk:"connection_id"`
+	AccessGroupID types.String          `tfsdk:"access_group_id"`
+	Query         types.String          `tfsdk:"query"`
+	MaxResults    types.Int64           `tfsdk:"max_results"`
+	MaxScanned    types.Int64           `tfsdk:"max_scanned"`
+	Events        []AuditEventItemModel `tfsdk:"events"`
+}
+
+// AuditEventItemModel is one audit event.
+type AuditEventItemModel struct {
+	EventID     types.String `tfsdk:"event_id"`
+	EventName   types.String `tfsdk:"event_name"`
+	ServiceID   types.String `tfsdk:"service_id"`
+	ServiceName types.String `tfsdk:"service_name"`
+	Created     types.String `tfsdk:"created"`
+	Message     types.Map    `tfsdk:"message"`
+}
+
+func (d *AuditEventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audit_events"
+}
+
+func (d *AuditEventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Audit events data source. Searches the PrivX audit log, newest event first.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source ID",
+				Computed:            true,
+			},
+			"event_types": schema.ListAttribute{
+				MarkdownDescription: "Only events with one of these event names (for example `SSH_CONNECTION_ESTABLISHED`) or numeric event IDs. " +
+					"The PrivX search API cannot filter by event type, so events are filtered as they are read, up to `max_scanned` events.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Only events at or after this time (RFC3339)",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "Only events at or before this time (RFC3339)",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Only events of this user",
+				Optional:            true,
+			},
+			"host_id": schema.StringAttribute{
+				MarkdownDescription: "Only events concerning this host",
+				Optional:            true,
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Only events of this connection",
+				Optional:            true,
+			},
+			"access_group_id": schema.StringAttribute{
+				MarkdownDescription: "Only events in this access group",
+				Optional:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Free-text search over the events",
+				Optional:            true,
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of events returned. Defaults to %d.", auditEventsDefaultMaxResults),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_scanned": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of events read when filtering by `event_types`. "+
+					"When it is reached the result may be incomplete and a warning is shown; narrow `start_time` or the other filters. Defaults to %d.", auditEventsDefaultMaxScanned),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching audit events",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"event_id": schema.StringAttribute{
+							MarkdownDescription: "Numeric event ID",
+							Computed:            true,
+						},
+						"event_name": schema.StringAttribute{
+							MarkdownDescription: "Event name",
+							Computed:            true,
+						},
+						"service_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the service that logged the event",
+							Computed:            true,
+						},
+						"service_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the service that logged the event",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: "Event time",
+							Computed:            true,
+						},
+						"message": schema.MapAttribute{
+							MarkdownDescription: "Event details",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AuditEventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating monitor", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = monitor.New(*connector)
+}
+
+func (d *AuditEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuditEventsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	search := &monitor.AuditEventSearch{
+		Keywords:      data.Query.ValueString(),
+		UserID:        data.UserID.ValueString(),
+		HostID:        data.HostID.ValueString(),
+		ConnectionID:  data.ConnectionID.ValueString(),
+		AccessGroupID: data.AccessGroupID.ValueString(),
+		StartTime:     data.StartTime.ValueString(),
+		EndTime:       data.EndTime.ValueString(),
+	}
+
+	// The search API has no event type filter; match names and IDs here.
+	eventTypes := make(map[string]bool, len(data.EventTypes))
+	for _, eventType := range data.EventTypes {
+		eventTypes[strings.ToUpper(eventType.ValueString())] = true
+	}
+	maxScanned := auditEventsDefaultMaxScanned
+	if !data.MaxScanned.IsNull() {
+		maxScanned = int(data.MaxScanned.ValueInt64())
+	}
+
+	maxResults := auditEventsDefaultMaxResults
+	if !data.MaxResults.IsNull() {
+		maxResults = int(data.MaxResults.ValueInt64())
+	}
+
+	data.Events = []AuditEventItemModel{}
+	for offset := 0; len(data.Events) < maxResults; offset += auditEventsPageSize {
+		page, err := d.client.SearchAuditEvents(search, filters.Paging(offset, auditEventsPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search audit events, got error: %s", err))
+			return
+		}
+
+		for _, event := range page.Items {
+			if len(data.Events) == maxResults {
+				break
+			}
+			if len(eventTypes) > 0 && !eventTypes[strings.ToUpper(event.EventName)] && !eventTypes[event.EventID] {
+				continue
+			}
+
+			message, diags := types.MapValueFrom(ctx, types.StringType, event.Message)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			data.Events = append(data.Events, AuditEventItemModel{
+				EventID:     types.StringValue(event.EventID),
+				EventName:   types.StringValue(event.EventName),
+				ServiceID:   types.StringValue(event.ServiceID),
+				ServiceName: types.StringValue(event.ServiceName),
+				Created:     types.StringValue(event.Created),
+				Message:     message,
+			})
+		}
+
+		if len(page.Items) < auditEventsPageSize {
+			break
+		}
+		if len(eventTypes) > 0 && offset+len(page.Items) >= maxScanned {
+			resp.Diagnostics.AddWarning(
+				"Audit event search stopped",
+				fmt.Sprintf("Read %d events and found %d matching event_types; older matching events may be missing. "+
+					"Narrow start_time or the other filters, or raise max_scanned.", offset+len(page.Items), len(data.Events)),
+			)
+			break
+		}
+	}
+
+	sortedTypes := make([]string, 0, len(eventTypes))
+	for eventType := range eventTypes {
+		sortedTypes = append(sortedTypes, eventType)
+	}
+	sort.Strings(sortedTypes)
+
+	data.ID = types.StringValue(strings.Join([]string{
+		strings.Join(sortedTypes, ","),
+		data.UserID.ValueString(),
+		data.HostID.ValueString(),
+		data.ConnectionID.ValueString(),
+		data.AccessGroupID.ValueString(),
+		data.StartTime.ValueString(),
+		data.EndTime.ValueString(),
+		data.Query.ValueString(),
+	}, "/"))
+
+	tflog.Debug(ctx, "Storing audit events into the state", map[string]interface{}{
+		"count": len(data.Events),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
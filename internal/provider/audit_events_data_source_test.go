@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAuditEventsDataSource(t *testing.T) {
+	cfg := `
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_audit_events" "recent" {
+  start_time  = "2020-01-01T00:00:00Z"
+  max_results = 5
+}
+
+data "privx_audit_events" "none" {
+  start_time  = "2020-01-01T00:00:00Z"
+  event_types = ["TF_ACC_NO_SUCH_EVENT"]
+  max_results = 5
+  max_scanned = 500
+}
+`
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.privx_audit_events.recent", "events.#"),
+					resource.TestCheckResourceAttrSet("data.privx_audit_events.recent", "events.0.event_name"),
+					resource.TestCheckResourceAttr("data.privx_audit_events.none", "events.#", "0"),
+				),
+			},
+		},
+	})
+}
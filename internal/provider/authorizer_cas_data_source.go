@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuthorizerCAsDataSource{}
+
+func NewAuthorizerCAsDataSource() datasource.DataSource {
+	return &AuthorizerCAsDataSource{}
+}
+
+// AuthorizerCAsDataSource defines the data source implementation.
+type AuthorizerCAsDataSource struct {
+	client *authorizer.Authorizer
+}
+
+// AuthorizerCAsDataSourceModel describes the data source data model.
+type AuthorizerCAsDataSourceModel struct {
+	ID                types.String            `tfsdk:"id"`
+	AccessGroupID     types.String            `tfsdk:"access_group_id"`
+	Type              types.String            `tfsdk:"type"`
+	CAs               []AuthorizerCAItemModel `tfsdk:"cas"`
+	TrustedUserCAKeys types.String            `tfsdk:"trusted_user_ca_keys"`
+	TLSTrustAnchor    types.String            `tfsdk:"tls_trust_anchor"`
+}
+
+// AuthorizerCAItemModel is one authorizer CA.
+type AuthorizerCAItemModel struct {
+	ID                types.String `tfsdk:"id"`
+	AccessGroupID     types.String `tfsdk:"access_group_id"`
+	Type              types.String `tfsdk:"type"`
+	Size              types.Int64  `tfsdk:"size"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	X509Certificate   types.String `tfsdk:"x509_certificate"`
+	Subject           types.String `tfsdk:"subject"`
+	NotBefore         types.String `tfsdk:"not_before"`
+	NotAfter          types.String `tfsdk:"not_after"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (d *AuthorizerCAsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authorizer_cas"
+}
+
+func (d *AuthorizerCAsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Authorizer CAs data source. Lists the authorizer CA keys hosts must trust, in formats ready for host deployment.",
+		Attributes: map[string]schema.Attribute{
+			"id": computed("Data source ID"),
+			"access_group_id": schema.StringAttribute{
+				MarkdownDescription: "Only CAs of this access group",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only CAs of this key type",
+				Optional:            true,
+			},
+			"cas": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching CAs",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":              computed("CA ID"),
+						"access_group_id": computed("Access group of the CA"),
+						"type":            computed("Key type"),
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "Key size in bits",
+							Computed:            true,
+						},
+						"public_key":         computed("Public key in OpenSSH format"),
+						"x509_certificate":   computed("CA certificate in PEM format, when the CA has one"),
+						"subject":            computed("Certificate subject"),
+						"not_before":         computed("Certificate validity start"),
+						"not_after":          computed("Certificate validity end"),
+						"fingerprint_sha256": computed("SHA256 fingerprint"),
+					},
+				},
+			},
+			"trusted_user_ca_keys": computed("Public keys of the matching CAs, one per line, for the sshd `TrustedUserCAKeys` file"),
+			"tls_trust_anchor":     computed("PEM trust anchor of the PrivX TLS endpoint"),
+		},
+	}
+}
+
+func (d *AuthorizerCAsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating authorizer client", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = authorizer.New(*connector)
+}
+
+func (d *AuthorizerCAsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuthorizerCAsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cas, err := d.client.GetCACertificates()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list authorizer CAs, got error: %s", err))
+		return
+	}
+
+	accessGroupID := data.AccessGroupID.ValueString()
+	keyType := data.Type.ValueString()
+
+	var keys []string
+	data.CAs = []AuthorizerCAItemModel{}
+	for _, ca := range cas.Items {
+		caAccessGroupID := ca.AccessGroupID
+		if caAccessGroupID == "" {
+			caAccessGroupID = ca.GroupID
+		}
+		if accessGroupID != "" && caAccessGroupID != accessGroupID {
+			continue
+		}
+		if keyType != "" && !strings.EqualFold(ca.Type, keyType) {
+			continue
+		}
+
+		item := AuthorizerCAItemModel{
+			ID:                types.StringValue(ca.ID),
+			AccessGroupID:     types.StringValue(caAccessGroupID),
+			Type:              types.StringValue(ca.Type),
+			Size:              types.Int64Value(int64(ca.Size)),
+			PublicKey:         types.StringValue(ca.PublicKeyString),
+			X509Certificate:   types.StringValue(ca.X509Certificate),
+			Subject:           types.StringValue(ca.Subject),
+			NotBefore:         types.StringValue(ca.NotBefore),
+			NotAfter:          types.StringValue(ca.NotAfter),
+			FingerprintSHA256: types.StringValue(ca.FingerPrintSHA256),
+		}
+		if info, err := parseSSHPublicKey(ca.PublicKeyString); err == nil {
+			item.FingerprintSHA256 = types.StringValue(info.FingerprintSHA256)
+		}
+		data.CAs = append(data.CAs, item)
+
+		if key := strings.TrimSpace(ca.PublicKeyString); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	trustedKeys := strings.Join(keys, "\n")
+	if trustedKeys != "" {
+		trustedKeys += "\n"
+	}
+	data.TrustedUserCAKeys = types.StringValue(trustedKeys)
+
+	anchor, err := d.client.GetSSLTrustAnchor()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read TLS trust anchor, got error: %s", err))
+		return
+	}
+	data.TLSTrustAnchor = types.StringValue(anchor.TrustAnchor)
+
+	data.ID = types.StringValue(accessGroupID + "/" + keyType)
+
+	tflog.Debug(ctx, "Storing authorizer CAs into the state", map[string]interface{}{
+		"count": len(data.CAs),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
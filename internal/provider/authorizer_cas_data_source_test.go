@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAuthorizerCAsDataSource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name    = "tf-acc-ca-%[1]s"
+  comment = "temp access group for authorizer CA test"
+}
+
+resource "privx_role" "test" {
+  name            = "tf-acc-ca-%[1]s"
+  access_group_id = privx_access_group.test.id
+
+  permissions  = ["users-view"]
+  permit_agent = false
+
+  source_rules = jsonencode({
+    type  = "GROUP"
+    match = "ANY"
+    rules = []
+  })
+}
+
+resource "privx_role_principal_key" "test" {
+  role_id = privx_role.test.id
+}
+
+data "privx_authorizer_cas" "test" {
+  access_group_id = privx_access_group.test.id
+}
+
+data "privx_role_principal_keys" "test" {
+  role_id = privx_role.test.id
+
+  depends_on = [privx_role_principal_key.test]
+}
+`, suffix)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.privx_authorizer_cas.test", "cas.0.access_group_id", "privx_access_group.test", "id"),
+					resource.TestCheckResourceAttrSet("data.privx_authorizer_cas.test", "cas.0.public_key"),
+					resource.TestCheckResourceAttrSet("data.privx_authorizer_cas.test", "trusted_user_ca_keys"),
+					resource.TestCheckResourceAttrSet("data.privx_authorizer_cas.test", "tls_trust_anchor"),
+					resource.TestCheckResourceAttrSet("data.privx_role_principal_keys.test", "keys.0.fingerprint_sha256"),
+					resource.TestCheckResourceAttrSet("data.privx_role_principal_keys.test", "authorized_keys"),
+				),
+			},
+		},
+	})
+}
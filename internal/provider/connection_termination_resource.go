@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/connectionmanager"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConnectionTerminationResource{}
+
+func NewConnectionTerminationResource() resource.Resource {
+	return &ConnectionTerminationResource{}
+}
+
+// ConnectionTerminationResource defines the resource implementation.
+type ConnectionTerminationResource struct {
+	client *connectionmanager.ConnectionManager
+}
+
+// ConnectionTerminationResourceModel describes the resource data model.
+type ConnectionTerminationResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ConnectionID types.String `tfsdk:"connection_id"`
+	HostID       types.String `tfsdk:"host_id"`
+	UserID       types.String `tfsdk:"user_id"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	Terminated   types.String `tfsdk:"terminated"`
+}
+
+func (r *ConnectionTerminationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_termination"
+}
+
+func (r *ConnectionTerminationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	exactlyOne := []validator.String{
+		stringvalidator.ExactlyOneOf(path.MatchRoot("connection_id"), path.MatchRoot("host_id"), path.MatchRoot("user_id")),
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Connection termination resource. Terminates a live connection, or all live connections to a host or of a user, " +
+			"when created. Change `triggers` to terminate again. Destroying the resource only removes it from the state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the connection to terminate",
+				Optional:            true,
+				Validators:          exactlyOne,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"host_id": schema.StringAttribute{
+				MarkdownDescription: "Terminate all connections to this host",
+				Optional:            true,
+				Validators:          exactlyOne,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Terminate all connections of this user",
+				Optional:            true,
+				Validators:          exactlyOne,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values; any change terminates the connections again",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"terminated": schema.StringAttribute{
+				MarkdownDescription: "Time the termination was requested (RFC3339)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ConnectionTerminationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating connection manager", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = connectionmanager.New(*connector)
+}
+
+func (r *ConnectionTerminationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ConnectionTerminationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		id  string
+		err error
+	)
+	switch {
+	case !data.ConnectionID.IsNull():
+		id = "connection/" + data.ConnectionID.ValueString()
+		err = r.client.TerminateConnection(data.ConnectionID.ValueString())
+	case !data.HostID.IsNull():
+		id = "host/" + data.HostID.ValueString()
+		err = r.client.TerminateConnectionsByHost(data.HostID.ValueString())
+	default:
+		id = "user/" + data.UserID.ValueString()
+		err = r.client.TerminateConnectionsByUser(data.UserID.ValueString())
+	}
+
+	tflog.Info(ctx, "Terminating connections", map[string]interface{}{
+		"target": id,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to terminate %s, got error: %s", id, err))
+		return
+	}
+
+	data.ID = types.StringValue(id)
+	data.Terminated = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the state as is; a termination has nothing to refresh.
+func (r *ConnectionTerminationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called; every argument forces replacement.
+func (r *ConnectionTerminationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ConnectionTerminationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the resource from the state.
+func (r *ConnectionTerminationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
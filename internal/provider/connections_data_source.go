@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/connectionmanager"
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConnectionsDataSource{}
+
+// connectionsPageSize is the page size used when searching connections.
+const connectionsPageSize = 100
+
+func NewConnectionsDataSource() datasource.DataSource {
+	return &ConnectionsDataSource{}
+}
+
+// ConnectionsDataSource defines the data source implementation.
+type ConnectionsDataSource struct {
+	client *connectionmanager.ConnectionManager
+}
+
+// ConnectionsDataSourceModel describes the data source data model.
+type ConnectionsDataSourceModel struct {
+	ID              types.String          `tfsdk:"id"`
+	HostID          types.String          `tfsdk:"host_id"`
+	UserID          types.String          `tfsdk:"user_id"`
+	Protocol        types.String          `tfsdk:"protocol"`
+	Status          types.String          `tfsdk:"status"`
+	ConnectedAfter  types.String          `tfsdk:"connected_after"`
+	ConnectedBefore types.String          `tfsdk:"connected_before"`
+	MaxResults      types.Int64           `tfsdk:"max_results"`
+	Connections     []ConnectionItemModel `tfsdk:"connections"`
+}
+
+// ConnectionItemModel is one connection returned by the connection manager.
+type ConnectionItemModel struct {
+	ID                types.String `tfsdk:"id"`
+	Protocol          types.String `tfsdk:"protocol"`
+	Mode              types.String `tfsdk:"mode"`
+	Status            types.String `tfsdk:"status"`
+	UserID            types.String `tfsdk:"user_id"`
+	UserDisplayName   types.String `tfsdk:"user_display_name"`
+	HostID            types.String `tfsdk:"host_id"`
+	HostCommonName    types.String `tfsdk:"host_common_name"`
+	TargetHostAddress types.String `tfsdk:"target_host_address"`
+	TargetHostAccount types.String `tfsdk:"target_host_account"`
+	RemoteAddress     types.String `tfsdk:"remote_address"`
+	Connected         types.String `tfsdk:"connected"`
+	Disconnected      types.String `tfsdk:"disconnected"`
+	LastActivity      types.String `tfsdk:"last_activity"`
+	Duration          types.Int64  `tfsdk:"duration"`
+	BytesIn           types.Int64  `tfsdk:"bytes_in"`
+	BytesOut          types.Int64  `tfsdk:"bytes_out"`
+}
+
+func (d *ConnectionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connections"
+}
+
+func (d *ConnectionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Connections data source. Searches the PrivX connection manager, newest connection first.",
+		Attributes: map[string]schema.Attribute{
+			"id": computed("Data source ID"),
+			"host_id": schema.StringAttribute{
+				MarkdownDescription: "Only connections to this host",
+				Optional:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Only connections of this user",
+				Optional:            true,
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "Only connections of this type, for example `SSH`, `RDP`, `VNC`, `WEB` or `DB`",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only connections in this status, for example `CONNECTED` for live sessions",
+				Optional:            true,
+			},
+			"connected_after": schema.StringAttribute{
+				MarkdownDescription: "Only connections opened at or after this time (RFC3339)",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"connected_before": schema.StringAttribute{
+				MarkdownDescription: "Only connections opened at or before this time (RFC3339)",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of connections returned. All matching connections are returned when unset; when more connections match, a warning is reported.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"connections": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching connections",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                  computed("Connection ID"),
+						"protocol":            computed("Connection type"),
+						"mode":                computed("Connection mode"),
+						"status":              computed("Connection status"),
+						"user_id":             computed("ID of the connecting user"),
+						"user_display_name":   computed("Display name of the connecting user"),
+						"host_id":             computed("ID of the target host"),
+						"host_common_name":    computed("Common name of the target host"),
+						"target_host_address": computed("Address connected to"),
+						"target_host_account": computed("Account used on the target host"),
+						"remote_address":      computed("Client address"),
+						"connected":           computed("Connection start time"),
+						"disconnected":        computed("Connection end time"),
+						"last_activity":       computed("Time of the last activity"),
+						"duration": schema.Int64Attribute{
+							MarkdownDescription: "Duration of the connection in seconds",
+							Computed:            true,
+						},
+						"bytes_in": schema.Int64Attribute{
+							MarkdownDescription: "Bytes received from the client",
+							Computed:            true,
+						},
+						"bytes_out": schema.Int64Attribute{
+							MarkdownDescription: "Bytes sent to the client",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ConnectionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating connection manager", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = connectionmanager.New(*connector)
+}
+
+func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConnectionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	search := &connectionmanager.ConnectionSearch{}
+	if v := data.HostID.ValueString(); v != "" {
+		search.TargetHost = []string{v}
+	}
+	if v := data.UserID.ValueString(); v != "" {
+		search.UserID = []string{v}
+	}
+	if v := data.Protocol.ValueString(); v != "" {
+		search.Type = []string{v}
+	}
+	if v := data.Status.ValueString(); v != "" {
+		search.Status = []string{v}
+	}
+	if !data.ConnectedAfter.IsNull() || !data.ConnectedBefore.IsNull() {
+		search.Connected = &connectionmanager.TimestampSearch{
+			Start: data.ConnectedAfter.ValueString(),
+			End:   data.ConnectedBefore.ValueString(),
+		}
+	}
+
+	// Zero means no limit.
+	maxResults := int(data.MaxResults.ValueInt64())
+
+	data.Connections = []ConnectionItemModel{}
+	truncated := false
+	for offset := 0; !truncated; offset += connectionsPageSize {
+		page, err := d.client.SearchConnections(search, filters.Paging(offset, connectionsPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search connections, got error: %s", err))
+			return
+		}
+
+		for _, conn := range page.Items {
+			if maxResults > 0 && len(data.Connections) == maxResults {
+				truncated = true
+				break
+			}
+			data.Connections = append(data.Connections, flattenConnection(conn))
+		}
+
+		if len(page.Items) < connectionsPageSize {
+			break
+		}
+	}
+	if truncated {
+		addTruncatedWarning(&resp.Diagnostics, "connections", maxResults)
+	}
+
+	data.ID = types.StringValue(strings.Join([]string{
+		data.HostID.ValueString(),
+		data.UserID.ValueString(),
+		data.Protocol.ValueString(),
+		data.Status.ValueString(),
+		data.ConnectedAfter.ValueString(),
+		data.ConnectedBefore.ValueString(),
+	}, "/"))
+
+	tflog.Debug(ctx, "Storing connections into the state", map[string]interface{}{
+		"count": len(data.Connections),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func flattenConnection(conn connectionmanager.Connection) ConnectionItemModel {
+	return ConnectionItemModel{
+		ID:                types.StringValue(conn.ID),
+		Protocol:          types.StringValue(conn.Type),
+		Mode:              types.StringValue(conn.Mode),
+		Status:            types.StringValue(conn.Status),
+		UserID:            types.StringValue(conn.User.ID),
+		UserDisplayName:   types.StringValue(conn.User.DisplayName),
+		HostID:            types.StringValue(conn.TargetHost.ID),
+		HostCommonName:    types.StringValue(conn.TargetHost.CommonName),
+		TargetHostAddress: types.StringValue(conn.TargetHostAddress),
+		TargetHostAccount: types.StringValue(conn.TargetHostAccount),
+		RemoteAddress:     types.StringValue(conn.RemoteAddress),
+		Connected:         types.StringValue(conn.Connected),
+		Disconnected:      types.StringValue(conn.Disconnected),
+		LastActivity:      types.StringValue(conn.LastActivity),
+		Duration:          types.Int64Value(int64(conn.Duration)),
+		BytesIn:           types.Int64Value(conn.BytesIn),
+		BytesOut:          types.Int64Value(conn.BytesOut),
+	}
+}
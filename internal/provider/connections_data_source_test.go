@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccConnectionsDataSource(t *testing.T) {
+	cfg := `
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_connections" "recent" {
+  connected_after = "2020-01-01T00:00:00Z"
+  max_results     = 10
+}
+`
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.privx_connections.recent", "id"),
+					resource.TestCheckResourceAttrSet("data.privx_connections.recent", "connections.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccConnectionTerminationResource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_connection_termination.test"
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_local_user" "test" {
+  username  = "tf-acc-term-%[1]s"
+  full_name = "Terraform provider Test User"
+  email     = "tf-acc-term-%[1]s@example.com"
+}
+
+# The user has no live sessions; terminating them must still succeed.
+resource "privx_connection_termination" "test" {
+  user_id = privx_local_user.test.id
+}
+`, suffix)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "terminated"),
+					resource.TestCheckResourceAttrPair(resourceName, "user_id", "privx_local_user.test", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/dbproxy"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DBProxyConfigDataSource{}
+
+func NewDBProxyConfigDataSource() datasource.DataSource {
+	return &DBProxyConfigDataSource{}
+}
+
+// DBProxyConfigDataSource defines the data source implementation.
+type DBProxyConfigDataSource struct {
+	client *dbproxy.DbProxy
+}
+
+// DBProxyConfigDataSourceModel describes the data source data model.
+type DBProxyConfigDataSourceModel struct {
+	ID                 types.String        `tfsdk:"id"`
+	CACertificateChain types.String        `tfsdk:"ca_certificate_chain"`
+	CACertificate      *DBProxyCACertModel `tfsdk:"ca_certificate"`
+}
+
+// DBProxyCACertModel describes the DB proxy CA certificate.
+type DBProxyCACertModel struct {
+	Subject           types.String `tfsdk:"subject"`
+	Issuer            types.String `tfsdk:"issuer"`
+	Serial            types.String `tfsdk:"serial"`
+	NotBefore         types.String `tfsdk:"not_before"`
+	NotAfter          types.String `tfsdk:"not_after"`
+	FingerprintSHA1   types.String `tfsdk:"fingerprint_sha1"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (d *DBProxyConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_db_proxy_config"
+}
+
+func (d *DBProxyConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DB proxy configuration data source. Reads the CA that signs the DB proxy server certificates, " +
+			"for configuring database clients (for example `sslrootcert` or `--ssl-ca`) to trust it.",
+		Attributes: map[string]schema.Attribute{
+			"id":                   computed("Static ID for this singleton config"),
+			"ca_certificate_chain": computed("DB proxy CA certificate chain (PEM)"),
+			"ca_certificate": schema.SingleNestedAttribute{
+				MarkdownDescription: "DB proxy CA certificate metadata (if available)",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"subject":            computed("Certificate subject"),
+					"issuer":             computed("Certificate issuer"),
+					"serial":             computed("Certificate serial number"),
+					"not_before":         computed("Certificate validity start"),
+					"not_after":          computed("Certificate validity end"),
+					"fingerprint_sha1":   computed("SHA1 fingerprint"),
+					"fingerprint_sha256": computed("SHA256 fingerprint"),
+				},
+			},
+		},
+	}
+}
+
+func (d *DBProxyConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating DB proxy client", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = dbproxy.New(*connector)
+}
+
+func (d *DBProxyConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	conf, err := d.client.GetDbProxyConfig()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DB proxy config, got error: %s", err))
+		return
+	}
+
+	data := DBProxyConfigDataSourceModel{
+		ID:                 types.StringValue("db-proxy-config"),
+		CACertificateChain: types.StringValue(conf.Chain),
+	}
+
+	if conf.CACertificate != nil {
+		data.CACertificate = &DBProxyCACertModel{
+			Subject:           types.StringValue(conf.CACertificate.Subject),
+			Issuer:            types.StringValue(conf.CACertificate.Issuer),
+			Serial:            types.StringValue(conf.CACertificate.Serial),
+			NotBefore:         types.StringValue(conf.CACertificate.NotBefore),
+			NotAfter:          types.StringValue(conf.CACertificate.NotAfter),
+			FingerprintSHA1:   types.StringValue(conf.CACertificate.FingerPrintSHA1),
+			FingerprintSHA256: types.StringValue(conf.CACertificate.FingerPrintSHA256),
+		}
+	}
+
+	tflog.Debug(ctx, "Storing DB proxy config into the state")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
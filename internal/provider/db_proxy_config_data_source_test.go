@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDBProxyConfigDataSource(t *testing.T) {
+	cfg := `
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_db_proxy_config" "this" {}
+`
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_db_proxy_config.this", "id", "db-proxy-config"),
+					resource.TestMatchResourceAttr("data.privx_db_proxy_config.this", "ca_certificate_chain",
+						regexp.MustCompile(`-----BEGIN CERTIFICATE-----`)),
+				),
+			},
+		},
+	})
+}
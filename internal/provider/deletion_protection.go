@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+
+	"terraform-provider-privx/internal/client"
+
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute is the schema of the deletion_protection
+// attribute.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Refuse to delete the object, including when a change forces its replacement. Set to `false` and apply before destroying it",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+}
+
+// checkDeletionProtection reports whether an object may be deleted. When
+// deletion_protection is set, or the provider does not allow the delete
+// because of protected_ids or read_only, it adds an error and returns false.
+func checkDeletionProtection(connector restapi.Connector, protection types.Bool, resourceType, id string, diags *diag.Diagnostics) bool {
+	if protection.ValueBool() {
+		diags.AddError(
+			"Deletion protection enabled",
+			fmt.Sprintf("%s %s has deletion_protection set. Set deletion_protection = false and apply before destroying it.", resourceType, id),
+		)
+		return false
+	}
+	if err := client.CheckDelete(connector, id); err != nil {
+		diags.AddError(
+			"Delete not allowed",
+			fmt.Sprintf("Refusing to delete %s: %s.", resourceType, err),
+		)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostDeployScriptDataSource{}
+
+func NewHostDeployScriptDataSource() datasource.DataSource {
+	return &HostDeployScriptDataSource{}
+}
+
+// HostDeployScriptDataSource defines the data source implementation.
+type HostDeployScriptDataSource struct {
+	client *authorizer.Authorizer
+}
+
+// HostDeployScriptDataSourceModel describes the data source data model.
+type HostDeployScriptDataSourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	TrustedClientID         types.String `tfsdk:"trusted_client_id"`
+	SessionID               types.String `tfsdk:"session_id"`
+	Script                  types.String `tfsdk:"script"`
+	ScriptBase64            types.String `tfsdk:"script_base64"`
+	PrincipalsCommandScript types.String `tfsdk:"principals_command_script"`
+}
+
+func (d *HostDeployScriptDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_deploy_script"
+}
+
+func (d *HostDeployScriptDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "PrivX host deployment script data source. Downloads the pre-configured script that registers a host with PrivX, " +
+			"and the `principals_command.sh` helper used by sshd.\n\n" +
+			"Every read creates a new download session in PrivX with a POST request, so each plan and apply writes to PrivX " +
+			"and `session_id` changes every time. The script content may change with it, so an instance that takes `script` or " +
+			"`script_base64` as `user_data` can show a diff on every plan; add `user_data` to the instance's `lifecycle.ignore_changes` " +
+			"when the script should only be used at first boot. Because of that POST request, the data source fails when the provider " +
+			"is in read-only mode.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source ID (same as trusted_client_id)",
+				Computed:            true,
+			},
+			"trusted_client_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the deployment trusted client the script authenticates as",
+				Required:            true,
+			},
+			"session_id": schema.StringAttribute{
+				MarkdownDescription: "Download session ID, new on every read",
+				Computed:            true,
+			},
+			"script": schema.StringAttribute{
+				MarkdownDescription: "Deployment script. It embeds the trusted client credentials.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"script_base64": schema.StringAttribute{
+				MarkdownDescription: "Deployment script, base64 encoded for cloud-init `write_files`",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"principals_command_script": schema.StringAttribute{
+				MarkdownDescription: "The `principals_command.sh` script for the sshd `AuthorizedPrincipalsCommand`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *HostDeployScriptDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Creating authorizer client", map[string]interface{}{
+		"connector": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = authorizer.New(*connector)
+}
+
+func (d *HostDeployScriptDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostDeployScriptDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trustedClientID := data.TrustedClientID.ValueString()
+
+	sessionResponse, err := d.client.GetDeployScriptSessions(trustedClientID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deploy script session, got error: %s", err))
+		return
+	}
+
+	sessionID := sessionResponse.SessionID
+	data.SessionID = types.StringValue(sessionID)
+
+	tempDir, err := os.MkdirTemp("", "privx-deploy-script-")
+	if err != nil {
+		resp.Diagnostics.AddError("File System Error", fmt.Sprintf("Unable to create temporary directory, got error: %s", err))
+		return
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(tempDir); removeErr != nil {
+			tflog.Warn(ctx, "Failed to clean up temporary directory", map[string]interface{}{
+				"temp_dir": tempDir,
+				"error":    removeErr.Error(),
+			})
+		}
+	}()
+
+	scriptFileName := filepath.Join(tempDir, "deploy.sh")
+	if err := d.client.DownloadDeployScript(trustedClientID, sessionID, scriptFileName); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download deploy script, got error: %s", err))
+		return
+	}
+
+	// #nosec G304 -- scriptFileName is created in a fresh temp dir controlled by us, not user input.
+	script, err := os.ReadFile(scriptFileName)
+	if err != nil {
+		resp.Diagnostics.AddError("File System Error", fmt.Sprintf("Unable to read downloaded deploy script, got error: %s", err))
+		return
+	}
+
+	principalsFileName := filepath.Join(tempDir, "principals_command.sh")
+	if err := d.client.DownloadPrincipalCommandScript(principalsFileName); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to download principals command script, got error: %s", err))
+		return
+	}
+
+	// #nosec G304 -- principalsFileName is created in a fresh temp dir controlled by us, not user input.
+	principals, err := os.ReadFile(principalsFileName)
+	if err != nil {
+		resp.Diagnostics.AddError("File System Error", fmt.Sprintf("Unable to read downloaded principals command script, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(trustedClientID)
+	data.Script = types.StringValue(string(script))
+	data.ScriptBase64 = types.StringValue(base64.StdEncoding.EncodeToString(script))
+	data.PrincipalsCommandScript = types.StringValue(string(principals))
+
+	tflog.Debug(ctx, "Storing host deploy script into the state", map[string]interface{}{
+		"trusted_client_id": trustedClientID,
+		"session_id":        sessionID,
+		"script_size":       len(script),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// testAccHostDeployScriptPreCheck skips unless a deployment trusted client
+// has been named, as the provider cannot create one.
+func testAccHostDeployScriptPreCheck(t *testing.T) {
+	if os.Getenv("PRIVX_ACC_DEPLOY_TRUSTED_CLIENT_ID") == "" {
+		t.Skip(
+			"Skipping host deploy script acceptance test. " +
+				"Set PRIVX_ACC_DEPLOY_TRUSTED_CLIENT_ID to the ID of a deployment trusted client to enable.",
+		)
+	}
+}
+
+func TestAccHostDeployScriptDataSource(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	testAccHostDeployScriptPreCheck(t)
+
+	trustedClientID := os.Getenv("PRIVX_ACC_DEPLOY_TRUSTED_CLIENT_ID")
+	dataSourceName := "data.privx_host_deploy_script.test"
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_host_deploy_script" "test" {
+  trusted_client_id = %q
+}
+`, trustedClientID)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", trustedClientID),
+					resource.TestCheckResourceAttrSet(dataSourceName, "session_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "script"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "script_base64"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "principals_command_script"),
+				),
+			},
+		},
+	})
+}
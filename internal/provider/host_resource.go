@@ -6,8 +6,11 @@ import (
 	"sort"
 	"terraform-provider-privx/internal/utils"
 
+	"github.com/SSHcom/privx-sdk-go/v2/api/connectionmanager"
 	"github.com/SSHcom/privx-sdk-go/v2/api/hoststore"
 	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -26,6 +30,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HostResource{}
 var _ resource.ResourceWithImportState = &HostResource{}
+var _ resource.ResourceWithValidateConfig = &HostResource{}
+
+// hostServiceDB is the service type of database proxy services.
+const hostServiceDB = "DB"
 
 func NewHostResource() resource.Resource {
 	return &HostResource{}
@@ -33,7 +41,9 @@ func NewHostResource() resource.Resource {
 
 // HostResource defines the resource implementation.
 type HostResource struct {
-	client *hoststore.HostStore
+	client           *hoststore.HostStore
+	connectionClient *connectionmanager.ConnectionManager
+	connector        restapi.Connector
 }
 
 // HostResourceModel contains PrivX host information.
@@ -72,6 +82,9 @@ type HostResourceModel struct {
 	UpdatedBy               types.String     `tfsdk:"updated_by"`
 	PasswordRotation        types.Object     `tfsdk:"password_rotation"`
 	StandAloneHost          types.Bool       `tfsdk:"stand_alone_host"`
+	TerminateOnDestroy      types.Bool       `tfsdk:"terminate_connections_on_destroy"`
+	AdoptExisting           types.Bool       `tfsdk:"adopt_existing"`
+	DeletionProtection      types.Bool       `tfsdk:"deletion_protection"`
 }
 
 type PasswordRotationModel struct {
@@ -89,13 +102,22 @@ type PasswordRotationModel struct {
 
 // ServiceModel represents a host service.
 type ServiceModel struct {
-	Service                types.String `tfsdk:"service"`
-	Address                types.String `tfsdk:"address"`
-	Port                   types.Int64  `tfsdk:"port"`
-	UseForPasswordRotation types.Bool   `tfsdk:"use_for_password_rotation"`
-	SSHTunnelPort          types.Int64  `tfsdk:"ssh_tunnel_port"`
-	UsePlaintextVNC        types.Bool   `tfsdk:"use_plaintext_vnc"`
-	Source                 types.String `tfsdk:"source"`
+	Service                types.String    `tfsdk:"service"`
+	Address                types.String    `tfsdk:"address"`
+	Port                   types.Int64     `tfsdk:"port"`
+	UseForPasswordRotation types.Bool      `tfsdk:"use_for_password_rotation"`
+	SSHTunnelPort          types.Int64     `tfsdk:"ssh_tunnel_port"`
+	UsePlaintextVNC        types.Bool      `tfsdk:"use_plaintext_vnc"`
+	Source                 types.String    `tfsdk:"source"`
+	DB                     *ServiceDBModel `tfsdk:"db"`
+}
+
+// ServiceDBModel represents the database proxy settings of a DB service.
+type ServiceDBModel struct {
+	Protocol                   types.String `tfsdk:"protocol"`
+	TLSCertificateValidation   types.String `tfsdk:"tls_certificate_validation"`
+	TLSCertificateTrustAnchors types.String `tfsdk:"tls_certificate_trust_anchors"`
+	AuditSkipBytes             types.Int64  `tfsdk:"audit_skip_bytes"`
 }
 
 // PrincipalModel represents a host principal.
@@ -215,6 +237,7 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 			"common_name": schema.StringAttribute{
 				MarkdownDescription: "Host common name",
 				Required:            true,
@@ -441,6 +464,13 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Whether this is a standalone host (not managed by a discovery source).",
 				Computed:            true,
 			},
+			"terminate_connections_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Terminate all live connections to the host before it is deleted",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"adopt_existing": adoptExistingAttribute("common_name"),
 			"tags": schema.ListAttribute{
 				MarkdownDescription: "List of tags for the host (order may change due to API sorting)",
 				ElementType:         types.StringType,
@@ -492,6 +522,42 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 							Computed:            true,
 							Default:             stringdefault.StaticString("UI"),
 						},
+						"db": schema.SingleNestedAttribute{
+							MarkdownDescription: "Database proxy settings. Required for, and only allowed on, `DB` services. " +
+								"PrivX proxies any database on the target, so the database name is chosen by the client when connecting.",
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"protocol": schema.StringAttribute{
+									MarkdownDescription: "Database protocol, `postgres` or `mysql`",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf("postgres", "mysql"),
+									},
+								},
+								"tls_certificate_validation": schema.StringAttribute{
+									MarkdownDescription: "Validation of the database server certificate, `ENABLED` or `DISABLED`",
+									Optional:            true,
+									Computed:            true,
+									Default:             stringdefault.StaticString("DISABLED"),
+									Validators: []validator.String{
+										stringvalidator.OneOf("ENABLED", "DISABLED"),
+									},
+								},
+								"tls_certificate_trust_anchors": schema.StringAttribute{
+									MarkdownDescription: "PEM certificates trusted when validating the database server certificate",
+									Optional:            true,
+								},
+								"audit_skip_bytes": schema.Int64Attribute{
+									MarkdownDescription: "Bytes of each query result left out of the audit trail",
+									Optional:            true,
+									Computed:            true,
+									Default:             int64default.StaticInt64(0),
+									Validators: []validator.Int64{
+										int64validator.AtLeast(0),
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -867,6 +933,51 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	}
 }
 
+func (r *HostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var services types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("services"), &services)...)
+	if resp.Diagnostics.HasError() || services.IsNull() || services.IsUnknown() {
+		return
+	}
+
+	var serviceModels []ServiceModel
+	resp.Diagnostics.Append(services.ElementsAs(ctx, &serviceModels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, sm := range serviceModels {
+		if sm.Service.IsUnknown() {
+			continue
+		}
+		servicePath := path.Root("services").AtListIndex(i)
+
+		if sm.Service.ValueString() != hostServiceDB {
+			if sm.DB != nil {
+				resp.Diagnostics.AddAttributeError(servicePath.AtName("db"), "Invalid Service Settings",
+					fmt.Sprintf("db is only allowed on %s services, not %s", hostServiceDB, sm.Service.ValueString()))
+			}
+			continue
+		}
+
+		if sm.DB == nil {
+			resp.Diagnostics.AddAttributeError(servicePath.AtName("db"), "Missing Service Settings",
+				fmt.Sprintf("%s services require a db block with the database protocol", hostServiceDB))
+		}
+		// The port defaults to SSH; a database listener never is.
+		if sm.Port.IsNull() {
+			resp.Diagnostics.AddAttributeError(servicePath.AtName("port"), "Missing Service Port",
+				fmt.Sprintf("%s services require an explicit port", hostServiceDB))
+		}
+		if sm.DB != nil && !sm.DB.TLSCertificateTrustAnchors.IsNull() && !sm.DB.TLSCertificateValidation.IsUnknown() &&
+			sm.DB.TLSCertificateValidation.ValueString() != "ENABLED" {
+			resp.Diagnostics.AddAttributeError(servicePath.AtName("db").AtName("tls_certificate_trust_anchors"), "Invalid Service Settings",
+				"tls_certificate_trust_anchors requires tls_certificate_validation = \"ENABLED\"")
+		}
+	}
+}
+
 func (r *HostResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -886,6 +997,8 @@ func (r *HostResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	})
 
 	r.client = hoststore.New(*connector)
+	r.connector = *connector
+	r.connectionClient = connectionmanager.New(*connector)
 }
 
 func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -934,6 +1047,14 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 			UsePlainTextVNC:        sm.UsePlaintextVNC.ValueBool(),
 			Source:                 sm.Source.ValueString(),
 		}
+		if sm.DB != nil {
+			service.DB = hoststore.HostServiceDBParameters{
+				Protocol:                   sm.DB.Protocol.ValueString(),
+				TLSCertificateValidation:   sm.DB.TLSCertificateValidation.ValueString(),
+				TLSCertificateTrustAnchors: sm.DB.TLSCertificateTrustAnchors.ValueString(),
+				AuditSkipBytes:             sm.DB.AuditSkipBytes.ValueInt64(),
+			}
+		}
 		services = append(services, service)
 	}
 
@@ -1243,6 +1364,33 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("hoststore.Host model used: %+v", host))
+
+	if data.AdoptExisting.ValueBool() {
+		existing, err := findHostByCommonName(r.client, host.CommonName)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up existing host %q, got error: %s", host.CommonName, err))
+			return
+		}
+		if existing != nil {
+			host.ID = existing.ID
+			if err := r.client.UpdateHost(existing.ID, &host); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update adopted host %s, got error: %s", existing.ID, err))
+				return
+			}
+			warnAdopted(ctx, &resp.Diagnostics, "privx_host", host.CommonName, existing.ID)
+
+			data.ID = types.StringValue(existing.ID)
+			hostRead, err := r.client.GetHost(existing.ID)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read adopted host, got error: %s", err))
+				return
+			}
+			r.populateHostModel(ctx, data, hostRead)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	createdHost, err := r.client.CreateHost(&host)
 	if err != nil {
 		tflog.Error(ctx, "CreateHost failed", map[string]any{
@@ -1307,6 +1455,15 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	r.populateHostModel(ctx, data, host)
+	if data.TerminateOnDestroy.IsNull() {
+		data.TerminateOnDestroy = types.BoolValue(false)
+	}
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
 
 	tflog.Debug(ctx, "Storing host into the state", map[string]interface{}{
 		"state": fmt.Sprintf("%+v", data),
@@ -1436,6 +1593,14 @@ func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			UsePlainTextVNC:        sm.UsePlaintextVNC.ValueBool(),
 			Source:                 sm.Source.ValueString(),
 		}
+		if sm.DB != nil {
+			service.DB = hoststore.HostServiceDBParameters{
+				Protocol:                   sm.DB.Protocol.ValueString(),
+				TLSCertificateValidation:   sm.DB.TLSCertificateValidation.ValueString(),
+				TLSCertificateTrustAnchors: sm.DB.TLSCertificateTrustAnchors.ValueString(),
+				AuditSkipBytes:             sm.DB.AuditSkipBytes.ValueInt64(),
+			}
+		}
 		services = append(services, service)
 	}
 	currentHost.Services = services
@@ -1693,6 +1858,23 @@ func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if !checkDeletionProtection(r.connector, data.DeletionProtection, "privx_host", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if data.TerminateOnDestroy.ValueBool() {
+		tflog.Info(ctx, "Terminating connections to host", map[string]any{
+			"id": data.ID.ValueString(),
+		})
+		if err := r.connectionClient.TerminateConnectionsByHost(data.ID.ValueString()); err != nil && !utils.IsPrivxNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to terminate connections to host, got error: %s", err),
+			)
+			return
+		}
+	}
+
 	err := r.client.DeleteHost(data.ID.ValueString())
 	if err != nil {
 		if utils.IsPrivxNotFound(err) {
@@ -1840,6 +2022,21 @@ func (r *HostResource) populateHostModel(ctx context.Context, data *HostResource
 			UsePlaintextVNC:        types.BoolValue(service.UsePlainTextVNC),
 			Source:                 types.StringValue(service.Source),
 		}
+		if service.Service == hostServiceDB {
+			tlsValidation := service.DB.TLSCertificateValidation
+			if tlsValidation == "" {
+				tlsValidation = "DISABLED"
+			}
+			data.Services[i].DB = &ServiceDBModel{
+				Protocol:                   types.StringValue(service.DB.Protocol),
+				TLSCertificateValidation:   types.StringValue(tlsValidation),
+				TLSCertificateTrustAnchors: types.StringNull(),
+				AuditSkipBytes:             types.Int64Value(service.DB.AuditSkipBytes),
+			}
+			if service.DB.TLSCertificateTrustAnchors != "" {
+				data.Services[i].DB.TLSCertificateTrustAnchors = types.StringValue(service.DB.TLSCertificateTrustAnchors)
+			}
+		}
 	}
 
 	// Convert principals - preserve original passphrase values to avoid sensitive attribute issues
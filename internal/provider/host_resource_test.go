@@ -79,6 +79,77 @@ func TestAccHostResource_passwordRotation(t *testing.T) {
 	})
 }
 
+func TestAccHostResource_dbService(t *testing.T) {
+	resourceName := "privx_host.test"
+	suffix := acctest.RandStringFromCharSet(6, "abcdefghijklmnopqrstuvwxyz0123456789")
+	hostIP := fmt.Sprintf("192.0.2.%d", acctest.RandIntRange(1, 250))
+	commonName := "tf-acc-host-db-" + suffix
+
+	cfg := func(validation string) string {
+		return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_access_group" "ag" {
+  name = "Default"
+}
+
+resource "privx_host" "test" {
+  common_name     = %[1]q
+  addresses       = [%[2]q]
+  access_group_id = data.privx_access_group.ag.id
+
+  services = [{
+    service = "DB"
+    address = %[2]q
+    port    = 5432
+    db = {
+      protocol                   = "postgres"
+      tls_certificate_validation = %[3]q
+    }
+  }]
+
+  principals = [{
+    principal  = "tf-acc-db"
+    passphrase = "InitPaSS-123"
+  }]
+}
+`, commonName, hostIP, validation)
+	}
+	cfgStep1 := cfg("DISABLED")
+	cfgStep2 := cfg("ENABLED")
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfgStep1)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfgStep2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgStep1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostHasService(resourceName, "DB"),
+					resource.TestCheckResourceAttr(resourceName, "services.0.db.protocol", "postgres"),
+					resource.TestCheckResourceAttr(resourceName, "services.0.db.tls_certificate_validation", "DISABLED"),
+				),
+			},
+			{
+				Config: cfgStep2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "services.0.db.tls_certificate_validation", "ENABLED"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckHostHasPrincipal(name, principal string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -0,0 +1,844 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HostSetResource{}
+var _ resource.ResourceWithModifyPlan = &HostSetResource{}
+var _ resource.ResourceWithValidateConfig = &HostSetResource{}
+
+// hostSetDefaultParallelism is the number of hosts written at a time when
+// parallelism is not set.
+const hostSetDefaultParallelism = 10
+
+func NewHostSetResource() resource.Resource {
+	return &HostSetResource{}
+}
+
+// HostSetResource defines the resource implementation.
+type HostSetResource struct {
+	client *hoststore.HostStore
+}
+
+// HostSetResourceModel describes a set of hosts managed together.
+type HostSetResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	AccessGroupID types.String `tfsdk:"access_group_id"`
+	Parallelism   types.Int64  `tfsdk:"parallelism"`
+	Hosts         types.Map    `tfsdk:"hosts"`
+	HostIDs       types.Map    `tfsdk:"host_ids"`
+	Digests       types.Map    `tfsdk:"digests"`
+}
+
+// HostSetHostModel is one host definition of a host set.
+type HostSetHostModel struct {
+	CommonName types.String `tfsdk:"common_name"`
+	Addresses  types.List   `tfsdk:"addresses"`
+	Services   types.List   `tfsdk:"services"`
+	Principals types.List   `tfsdk:"principals"`
+	Tags       types.Set    `tfsdk:"tags"`
+}
+
+// HostSetServiceModel is one service of a host in a host set.
+type HostSetServiceModel struct {
+	Service types.String `tfsdk:"service"`
+	Address types.String `tfsdk:"address"`
+	Port    types.Int64  `tfsdk:"port"`
+}
+
+// HostSetPrincipalModel is one principal of a host in a host set.
+type HostSetPrincipalModel struct {
+	Principal types.String `tfsdk:"principal"`
+	RoleIDs   types.Set    `tfsdk:"role_ids"`
+}
+
+func (r *HostSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_set"
+}
+
+func (r *HostSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Host set resource. Registers many hosts at once from a map of compact host definitions. " +
+			"Hosts are read in pages of the access group and written with bounded parallelism, and the state only keeps " +
+			"the ID and a digest of each host. Settings other than the ones listed here, such as host keys or password rotation, " +
+			"are left as they are in PrivX; principal options are kept for principals that stay on the host. " +
+			"Failures are reported per host; hosts that were written are kept in the state. Hosts that cannot be registered " +
+			"when the set is created are reported as warnings and left out of `host_ids`, so the next apply retries only those " +
+			"hosts instead of replacing the set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Host set ID (same as name)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the host set",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_group_id": schema.StringAttribute{
+				MarkdownDescription: "Access group of all hosts in the set",
+				Required:            true,
+			},
+			"parallelism": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of hosts created, updated or deleted at a time. Defaults to %d. "+
+					"The provider's `max_concurrent_requests` still caps the requests in flight.", hostSetDefaultParallelism),
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(hostSetDefaultParallelism),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"hosts": schema.MapNestedAttribute{
+				MarkdownDescription: "Hosts of the set keyed by a stable name of your choice. Changing a key replaces that host.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"common_name": schema.StringAttribute{
+							MarkdownDescription: "Host common name",
+							Required:            true,
+						},
+						"addresses": schema.ListAttribute{
+							MarkdownDescription: "Host addresses. The first one is the contact address.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"services": schema.ListNestedAttribute{
+							MarkdownDescription: "Services of the host",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"service": schema.StringAttribute{
+										MarkdownDescription: "Service type (e.g., SSH, RDP, HTTP)",
+										Required:            true,
+									},
+									"address": schema.StringAttribute{
+										MarkdownDescription: "Service address. Defaults to the first host address.",
+										Optional:            true,
+									},
+									"port": schema.Int64Attribute{
+										MarkdownDescription: "Service port",
+										Optional:            true,
+										Computed:            true,
+										Default:             int64default.StaticInt64(22),
+									},
+								},
+							},
+						},
+						"principals": schema.ListNestedAttribute{
+							MarkdownDescription: "Principals (target accounts) of the host",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"principal": schema.StringAttribute{
+										MarkdownDescription: "Account name on the host",
+										Required:            true,
+									},
+									"role_ids": schema.SetAttribute{
+										MarkdownDescription: "IDs of the roles allowed to use the principal",
+										ElementType:         types.StringType,
+										Optional:            true,
+									},
+								},
+							},
+						},
+						"tags": schema.SetAttribute{
+							MarkdownDescription: "Host tags",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"host_ids": schema.MapAttribute{
+				MarkdownDescription: "PrivX host IDs keyed like `hosts`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"digests": schema.MapAttribute{
+				MarkdownDescription: "SHA256 digest of the managed settings of each host, keyed like `hosts`. " +
+					"A digest that changes in a plan means the host differs from its definition.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *HostSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HostSetResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Hosts.IsNull() || data.Hosts.IsUnknown() {
+		return
+	}
+
+	hosts := map[string]HostSetHostModel{}
+	resp.Diagnostics.Append(data.Hosts.ElementsAs(ctx, &hosts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for key, host := range hosts {
+		if len(host.Addresses.Elements()) > 0 || host.Addresses.IsUnknown() || host.Services.IsUnknown() {
+			continue
+		}
+		var services []HostSetServiceModel
+		resp.Diagnostics.Append(host.Services.ElementsAs(ctx, &services, false)...)
+		for _, service := range services {
+			if service.Address.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("hosts").AtMapKey(key),
+					"Missing Service Address",
+					fmt.Sprintf("Host %s has no addresses, so its %s service needs an explicit address.", key, service.Service.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+// ModifyPlan plans the digest of every host definition so that hosts which
+// differ from PrivX show up as digest changes.
+func (r *HostSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan HostSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state HostSetResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	priorIDs := stringMap(ctx, state.HostIDs, &resp.Diagnostics)
+	priorDigests := stringMap(ctx, state.Digests, &resp.Diagnostics)
+
+	if plan.Hosts.IsUnknown() || plan.AccessGroupID.IsUnknown() {
+		plan.HostIDs = types.MapUnknown(types.StringType)
+		plan.Digests = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	hosts := map[string]HostSetHostModel{}
+	resp.Diagnostics.Append(plan.Hosts.ElementsAs(ctx, &hosts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := req.State.Raw.IsNull() || len(priorIDs) != len(hosts)
+	digests := make(map[string]attr.Value, len(hosts))
+	for key, host := range hosts {
+		expanded, known := expandHostSetHost(ctx, plan.AccessGroupID.ValueString(), host, &resp.Diagnostics)
+		if !known {
+			digests[key] = types.StringUnknown()
+			changed = true
+			continue
+		}
+		digest := hostSetDigest(expanded)
+		digests[key] = types.StringValue(digest)
+		if _, ok := priorIDs[key]; !ok || priorDigests[key] != digest {
+			changed = true
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	digestsValue, d := types.MapValue(types.StringType, digests)
+	resp.Diagnostics.Append(d...)
+	plan.Digests = digestsValue
+	if changed {
+		plan.HostIDs = types.MapUnknown(types.StringType)
+	} else {
+		plan.HostIDs = state.HostIDs
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *HostSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating hoststore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = hoststore.New(*connector)
+}
+
+func (r *HostSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data HostSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+	plannedDigests := data.Digests
+	createErrs, diags := r.apply(ctx, &data, map[string]string{}, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+
+	// An error would taint the set, and replacing it would delete the hosts
+	// that were created. Hosts that failed are left out of host_ids instead,
+	// so the next plan creates them again; the digests stay as planned.
+	addHostSetWarnings(&resp.Diagnostics, "Unable to Create Host", createErrs)
+	if len(createErrs) > 0 && !plannedDigests.IsUnknown() {
+		data.Digests = plannedDigests
+	}
+
+	tflog.Debug(ctx, "created host set resource", map[string]interface{}{
+		"name":   data.Name.ValueString(),
+		"hosts":  len(data.HostIDs.Elements()),
+		"failed": len(createErrs),
+	})
+
+	// Save the hosts that were created, even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data HostSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostIDs := stringMap(ctx, data.HostIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.readHosts(data.AccessGroupID.ValueString(), hostIDs)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read hosts of host set %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	ids := map[string]string{}
+	digests := map[string]string{}
+	for key, id := range hostIDs {
+		host, ok := remote[id]
+		if !ok {
+			tflog.Info(ctx, "Host of host set appears to be deleted, removing from state", map[string]interface{}{
+				"key": key,
+				"id":  id,
+			})
+			continue
+		}
+		ids[key] = id
+		digests[key] = hostSetDigest(host)
+	}
+
+	resp.Diagnostics.Append(setHostSetMaps(ctx, &data, ids, digests)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state HostSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorIDs := stringMap(ctx, state.HostIDs, &resp.Diagnostics)
+	priorDigests := stringMap(ctx, state.Digests, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+	createErrs, diags := r.apply(ctx, &data, priorIDs, priorDigests)
+	resp.Diagnostics.Append(diags...)
+	addHostSetErrors(&resp.Diagnostics, "Unable to Create Host", createErrs)
+
+	// Save the hosts that were reconciled, even if others failed
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data HostSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostIDs := stringMap(ctx, data.HostIDs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errs := runHostSetTasks(sortedMapKeys(hostIDs), int(data.Parallelism.ValueInt64()), func(key string) error {
+		if err := r.client.DeleteHost(hostIDs[key]); err != nil && !utils.IsPrivxNotFound(err) {
+			return err
+		}
+		return nil
+	})
+	addHostSetErrors(&resp.Diagnostics, "Unable to Delete Host", errs)
+}
+
+// apply creates, updates and deletes hosts so that PrivX matches the planned
+// host definitions, and records the IDs and digests of the hosts that were
+// reconciled. Update and delete failures are reported per host; failed
+// creates are returned keyed by host so that the caller can decide how to
+// report them.
+func (r *HostSetResource) apply(ctx context.Context, data *HostSetResourceModel, priorIDs, priorDigests map[string]string) (map[string]error, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	hosts := map[string]HostSetHostModel{}
+	diags.Append(data.Hosts.ElementsAs(ctx, &hosts, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	accessGroupID := data.AccessGroupID.ValueString()
+	desired := make(map[string]*hoststore.Host, len(hosts))
+	for key, host := range hosts {
+		desired[key], _ = expandHostSetHost(ctx, accessGroupID, host, &diags)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var creates, updates, deletes []string
+	for _, key := range sortedMapKeys(desired) {
+		if _, ok := priorIDs[key]; !ok {
+			creates = append(creates, key)
+		} else if priorDigests[key] != hostSetDigest(desired[key]) {
+			updates = append(updates, key)
+		}
+	}
+	for _, key := range sortedMapKeys(priorIDs) {
+		if _, ok := desired[key]; !ok {
+			deletes = append(deletes, key)
+		}
+	}
+
+	tflog.Debug(ctx, "Reconciling host set", map[string]interface{}{
+		"name":    data.Name.ValueString(),
+		"create":  len(creates),
+		"update":  len(updates),
+		"delete":  len(deletes),
+		"current": len(priorIDs),
+	})
+
+	// Updates start from the current host so that settings the set does not
+	// manage are kept.
+	var remote map[string]*hoststore.Host
+	if len(updates) > 0 {
+		updateIDs := make(map[string]string, len(updates))
+		for _, key := range updates {
+			updateIDs[key] = priorIDs[key]
+		}
+		var err error
+		remote, err = r.readHosts(accessGroupID, updateIDs)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read hosts of host set %s, got error: %s", data.Name.ValueString(), err))
+			return nil, diags
+		}
+	}
+
+	var mu sync.Mutex
+	ids := make(map[string]string, len(desired))
+	digests := make(map[string]string, len(desired))
+	for key, id := range priorIDs {
+		ids[key] = id
+		if digest, ok := priorDigests[key]; ok {
+			digests[key] = digest
+		}
+	}
+	record := func(key, id, digest string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if id == "" {
+			delete(ids, key)
+			delete(digests, key)
+			return
+		}
+		ids[key] = id
+		digests[key] = digest
+	}
+
+	parallelism := int(data.Parallelism.ValueInt64())
+
+	createErrs := runHostSetTasks(creates, parallelism, func(key string) error {
+		host := desired[key]
+		created, err := r.client.CreateHost(host)
+		if err != nil {
+			return err
+		}
+		record(key, created.ID, hostSetDigest(host))
+		return nil
+	})
+
+	updateErrs := runHostSetTasks(updates, parallelism, func(key string) error {
+		host := desired[key]
+		current, ok := remote[priorIDs[key]]
+		if !ok {
+			// The host was deleted outside Terraform; register it again.
+			created, err := r.client.CreateHost(host)
+			if err != nil {
+				return err
+			}
+			record(key, created.ID, hostSetDigest(host))
+			return nil
+		}
+		mergeHostSetHost(current, host)
+		if err := r.client.UpdateHost(current.ID, current); err != nil {
+			return err
+		}
+		record(key, current.ID, hostSetDigest(current))
+		return nil
+	})
+	addHostSetErrors(&diags, "Unable to Update Host", updateErrs)
+
+	deleteErrs := runHostSetTasks(deletes, parallelism, func(key string) error {
+		if err := r.client.DeleteHost(priorIDs[key]); err != nil && !utils.IsPrivxNotFound(err) {
+			return err
+		}
+		record(key, "", "")
+		return nil
+	})
+	for key, err := range deleteErrs {
+		diags.AddError("Unable to Delete Host", fmt.Sprintf("Host %s (%s): %s", key, priorIDs[key], err))
+	}
+
+	diags.Append(setHostSetMaps(ctx, data, ids, digests)...)
+	return createErrs, diags
+}
+
+// readHosts returns the hosts with the given IDs keyed by ID. Hosts are read
+// in pages of the access group; hosts that have moved to another access
+// group are read one by one. Hosts that no longer exist are left out.
+func (r *HostSetResource) readHosts(accessGroupID string, hostIDs map[string]string) (map[string]*hoststore.Host, error) {
+	wanted := make(map[string]bool, len(hostIDs))
+	for _, id := range hostIDs {
+		wanted[id] = true
+	}
+
+	hosts := make(map[string]*hoststore.Host, len(wanted))
+	search := &hoststore.HostSearch{AccessGroupIDs: []string{accessGroupID}}
+	for offset := 0; len(hosts) < len(wanted); offset += hostsPageSize {
+		page, err := r.client.SearchHosts(search, filters.Paging(offset, hostsPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Items {
+			if host := &page.Items[i]; wanted[host.ID] {
+				hosts[host.ID] = host
+			}
+		}
+		if len(page.Items) < hostsPageSize {
+			break
+		}
+	}
+
+	for id := range wanted {
+		if _, ok := hosts[id]; ok {
+			continue
+		}
+		host, err := r.client.GetHost(id)
+		if err != nil {
+			if utils.IsPrivxNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		hosts[id] = host
+	}
+
+	return hosts, nil
+}
+
+// expandHostSetHost builds the PrivX host for a host definition. It returns
+// false when the definition still has unknown values.
+func expandHostSetHost(ctx context.Context, accessGroupID string, m HostSetHostModel, diags *diag.Diagnostics) (*hoststore.Host, bool) {
+	if m.CommonName.IsUnknown() || m.Addresses.IsUnknown() || m.Services.IsUnknown() || m.Principals.IsUnknown() || m.Tags.IsUnknown() {
+		return nil, false
+	}
+
+	host := &hoststore.Host{
+		CommonName:    m.CommonName.ValueString(),
+		AccessGroupID: accessGroupID,
+		Addresses:     []string{},
+		Services:      []hoststore.HostService{},
+		Principals:    []hoststore.HostPrincipals{},
+		Tags:          []string{},
+	}
+
+	// PrivX expects empty lists rather than nulls.
+	if !m.Addresses.IsNull() {
+		diags.Append(m.Addresses.ElementsAs(ctx, &host.Addresses, false)...)
+	}
+	if !m.Tags.IsNull() {
+		diags.Append(m.Tags.ElementsAs(ctx, &host.Tags, false)...)
+		sort.Strings(host.Tags)
+	}
+	if len(host.Addresses) > 0 {
+		host.ContactAddress = host.Addresses[0]
+	}
+
+	var services []HostSetServiceModel
+	diags.Append(m.Services.ElementsAs(ctx, &services, false)...)
+	for _, s := range services {
+		if s.Service.IsUnknown() || s.Address.IsUnknown() || s.Port.IsUnknown() {
+			return nil, false
+		}
+		address := s.Address.ValueString()
+		if address == "" {
+			address = host.ContactAddress
+		}
+		host.Services = append(host.Services, hoststore.HostService{
+			Service: s.Service.ValueString(),
+			Address: address,
+			Port:    int(s.Port.ValueInt64()),
+			Source:  "UI",
+		})
+	}
+
+	var principals []HostSetPrincipalModel
+	diags.Append(m.Principals.ElementsAs(ctx, &principals, false)...)
+	for _, p := range principals {
+		if p.Principal.IsUnknown() || p.RoleIDs.IsUnknown() {
+			return nil, false
+		}
+		var roleIDs []string
+		diags.Append(p.RoleIDs.ElementsAs(ctx, &roleIDs, false)...)
+		principal := hoststore.HostPrincipals{
+			Principal: p.Principal.ValueString(),
+			Roles:     []hoststore.HostRole{},
+		}
+		for _, id := range roleIDs {
+			principal.Roles = append(principal.Roles, hoststore.HostRole{ID: id})
+		}
+		host.Principals = append(host.Principals, principal)
+	}
+
+	return host, !diags.HasError()
+}
+
+// mergeHostSetHost applies the settings a host set manages to the current
+// host. Principals that stay on the host keep their other options.
+func mergeHostSetHost(current, desired *hoststore.Host) {
+	existing := make(map[string]hoststore.HostPrincipals, len(current.Principals))
+	for _, p := range current.Principals {
+		existing[p.Principal] = p
+	}
+
+	principals := make([]hoststore.HostPrincipals, 0, len(desired.Principals))
+	for _, p := range desired.Principals {
+		if e, ok := existing[p.Principal]; ok {
+			e.Roles = p.Roles
+			p = e
+		}
+		principals = append(principals, p)
+	}
+
+	current.CommonName = desired.CommonName
+	current.AccessGroupID = desired.AccessGroupID
+	current.Addresses = desired.Addresses
+	current.ContactAddress = desired.ContactAddress
+	current.Services = desired.Services
+	current.Principals = principals
+	current.Tags = desired.Tags
+}
+
+// hostSetDigest returns a digest of the settings a host set manages. It is
+// independent of the order of addresses, services, principals, roles and
+// tags.
+func hostSetDigest(host *hoststore.Host) string {
+	type service struct {
+		Service string `json:"service"`
+		Address string `json:"address"`
+		Port    int    `json:"port"`
+	}
+	type principal struct {
+		Principal string   `json:"principal"`
+		Roles     []string `json:"roles"`
+	}
+
+	addresses := append([]string{}, host.Addresses...)
+	sort.Strings(addresses)
+	tags := append([]string{}, host.Tags...)
+	sort.Strings(tags)
+
+	services := make([]service, 0, len(host.Services))
+	for _, s := range host.Services {
+		services = append(services, service{Service: s.Service, Address: s.Address, Port: s.Port})
+	}
+	sort.Slice(services, func(i, j int) bool {
+		a, b := services[i], services[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		return a.Port < b.Port
+	})
+
+	principals := make([]principal, 0, len(host.Principals))
+	for _, p := range host.Principals {
+		roles := make([]string, 0, len(p.Roles))
+		for _, role := range p.Roles {
+			roles = append(roles, role.ID)
+		}
+		sort.Strings(roles)
+		principals = append(principals, principal{Principal: p.Principal, Roles: roles})
+	}
+	sort.Slice(principals, func(i, j int) bool { return principals[i].Principal < principals[j].Principal })
+
+	canonical, _ := json.Marshal(struct {
+		CommonName    string      `json:"common_name"`
+		AccessGroupID string      `json:"access_group_id"`
+		Addresses     []string    `json:"addresses"`
+		Services      []service   `json:"services"`
+		Principals    []principal `json:"principals"`
+		Tags          []string    `json:"tags"`
+	}{host.CommonName, host.AccessGroupID, addresses, services, principals, tags})
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// runHostSetTasks calls task for every key, at most parallelism at a time,
+// and returns the errors keyed by key.
+func runHostSetTasks(keys []string, parallelism int, task func(key string) error) map[string]error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := map[string]error{}
+	slots := make(chan struct{}, parallelism)
+
+	for _, key := range keys {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := task(key); err != nil {
+				mu.Lock()
+				errs[key] = err
+				mu.Unlock()
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// addHostSetErrors reports per-host failures on the hosts attribute.
+func addHostSetErrors(diags *diag.Diagnostics, summary string, errs map[string]error) {
+	for _, key := range sortedMapKeys(errs) {
+		diags.AddAttributeError(path.Root("hosts").AtMapKey(key), summary, fmt.Sprintf("Host %s: %s", key, errs[key]))
+	}
+}
+
+// addHostSetWarnings reports per-host failures on the hosts attribute as
+// warnings.
+func addHostSetWarnings(diags *diag.Diagnostics, summary string, errs map[string]error) {
+	for _, key := range sortedMapKeys(errs) {
+		diags.AddAttributeWarning(path.Root("hosts").AtMapKey(key), summary, fmt.Sprintf("Host %s: %s", key, errs[key]))
+	}
+}
+
+func setHostSetMaps(ctx context.Context, data *HostSetResourceModel, ids, digests map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	idsValue, d := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	digestsValue, d := types.MapValueFrom(ctx, types.StringType, digests)
+	diags.Append(d...)
+
+	data.HostIDs = idsValue
+	data.Digests = digestsValue
+	return diags
+}
+
+// stringMap returns the elements of a known map of strings.
+func stringMap(ctx context.Context, m types.Map, diags *diag.Diagnostics) map[string]string {
+	values := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return values
+	}
+	diags.Append(m.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// sortedMapKeys returns the keys of m in order.
+func sortedMapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccHostSetResource(t *testing.T) {
+	resourceName := "privx_host_set.test"
+	suffix := acctest.RandStringFromCharSet(6, "abcdefghijklmnopqrstuvwxyz0123456789")
+
+	cfgStep1 := testAccHostSetConfig(suffix, `
+    web = {
+      common_name = "tf-acc-set-web-`+suffix+`"
+      addresses   = ["192.0.2.21"]
+      services    = [{ service = "SSH" }]
+      principals  = [{ principal = "deploy" }]
+      tags        = ["tf-acc", "web"]
+    }
+    db = {
+      common_name = "tf-acc-set-db-`+suffix+`"
+      addresses   = ["192.0.2.22"]
+      services    = [{ service = "SSH", port = 2222 }]
+    }
+`)
+	cfgStep2 := testAccHostSetConfig(suffix, `
+    web = {
+      common_name = "tf-acc-set-web-`+suffix+`"
+      addresses   = ["192.0.2.21", "198.51.100.21"]
+      services    = [{ service = "SSH" }]
+      principals  = [{ principal = "deploy" }, { principal = "ops" }]
+      tags        = ["tf-acc", "web"]
+    }
+`)
+
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfgStep1)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfgStep2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgStep1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "tf-acc-set-"+suffix),
+					resource.TestCheckResourceAttr(resourceName, "host_ids.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "host_ids.web"),
+					resource.TestCheckResourceAttrSet(resourceName, "host_ids.db"),
+					resource.TestCheckResourceAttr(resourceName, "digests.%", "2"),
+				),
+			},
+			{
+				// Step 2: update one host and remove the other
+				Config: cfgStep2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host_ids.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "host_ids.web"),
+					resource.TestCheckNoResourceAttr(resourceName, "host_ids.db"),
+				),
+			},
+		},
+	})
+}
+
+// A host that PrivX rejects must not taint the set: the hosts that were
+// created stay, and the next apply only retries the failed one.
+func TestAccHostSetResource_partialCreate(t *testing.T) {
+	resourceName := "privx_host_set.test"
+	suffix := acctest.RandStringFromCharSet(6, "abcdefghijklmnopqrstuvwxyz0123456789")
+
+	good := `
+    good = {
+      common_name = "tf-acc-set-good-` + suffix + `"
+      addresses   = ["192.0.2.31"]
+      services    = [{ service = "SSH" }]
+    }
+`
+	cfgStep1 := testAccHostSetConfig(suffix, good+`
+    bad = {
+      common_name = "tf-acc-set-bad-`+suffix+`"
+      addresses   = ["192.0.2.32"]
+      services    = [{ service = "TF-ACC-NO-SUCH-SERVICE" }]
+    }
+`)
+	cfgStep2 := testAccHostSetConfig(suffix, good)
+
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfgStep1)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfgStep2)
+
+	var goodID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The bad host fails with a warning and is planned again
+				Config:             cfgStep1,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host_ids.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "host_ids.good"),
+					resource.TestCheckNoResourceAttr(resourceName, "host_ids.bad"),
+					func(s *terraform.State) error {
+						goodID = s.RootModule().Resources[resourceName].Primary.Attributes["host_ids.good"]
+						return nil
+					},
+				),
+			},
+			{
+				// Dropping the bad host updates the set in place
+				Config: cfgStep2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host_ids.%", "1"),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources[resourceName].Primary.Attributes["host_ids.good"]
+						if id != goodID {
+							return fmt.Errorf("host good was replaced: %s, want %s", id, goodID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccHostSetConfig(suffix, hosts string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_access_group" "ag" {
+  name = "Default"
+}
+
+resource "privx_host_set" "test" {
+  name            = "tf-acc-set-%s"
+  access_group_id = data.privx_access_group.ag.id
+  parallelism     = 2
+
+  hosts = {
+%s  }
+}
+`, suffix, hosts)
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/hoststore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &HostsDataSource{}
+
+// hostsPageSize is the page size used when searching hosts.
+const hostsPageSize = 100
+
+func NewHostsDataSource() datasource.DataSource {
+	return &HostsDataSource{}
+}
+
+// HostsDataSource defines the data source implementation.
+type HostsDataSource struct {
+	client *hoststore.HostStore
+}
+
+// HostsDataSourceModel describes the data source data model.
+type HostsDataSourceModel struct {
+	ID                  types.String     `tfsdk:"id"`
+	Tags                []types.String   `tfsdk:"tags"`
+	AccessGroupID       types.String     `tfsdk:"access_group_id"`
+	SourceID            types.String     `tfsdk:"source_id"`
+	CloudProvider       types.String     `tfsdk:"cloud_provider"`
+	CloudProviderRegion types.String     `tfsdk:"cloud_provider_region"`
+	AddressCIDR         types.String     `tfsdk:"address_cidr"`
+	HostType            types.String     `tfsdk:"host_type"`
+	Service             types.String     `tfsdk:"service"`
+	Query               types.String     `tfsdk:"query"`
+	MaxResults          types.Int64      `tfsdk:"max_results"`
+	IDs                 []types.String   `tfsdk:"ids"`
+	Hosts               []HostsItemModel `tfsdk:"hosts"`
+}
+
+// HostsItemModel is one host returned by the host store.
+type HostsItemModel struct {
+	ID                  types.String            `tfsdk:"id"`
+	CommonName          types.String            `tfsdk:"common_name"`
+	ExternalID          types.String            `tfsdk:"external_id"`
+	InstanceID          types.String            `tfsdk:"instance_id"`
+	AccessGroupID       types.String            `tfsdk:"access_group_id"`
+	SourceID            types.String            `tfsdk:"source_id"`
+	CloudProvider       types.String            `tfsdk:"cloud_provider"`
+	CloudProviderRegion types.String            `tfsdk:"cloud_provider_region"`
+	HostType            types.String            `tfsdk:"host_type"`
+	Disabled            types.String            `tfsdk:"disabled"`
+	Addresses           []types.String          `tfsdk:"addresses"`
+	Tags                []types.String          `tfsdk:"tags"`
+	Services            []HostsItemServiceModel `tfsdk:"services"`
+}
+
+// HostsItemServiceModel is one service of a listed host.
+type HostsItemServiceModel struct {
+	Service types.String `tfsdk:"service"`
+	Address types.String `tfsdk:"address"`
+	Port    types.Int64  `tfsdk:"port"`
+}
+
+func (d *HostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts"
+}
+
+func (d *HostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+	optional := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Optional: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Hosts data source. Searches the PrivX host store, paging through all matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": computed("Data source ID"),
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Only hosts with these tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"access_group_id":       optional("Only hosts in this access group"),
+			"source_id":             optional("Only hosts from this host source"),
+			"cloud_provider":        optional("Only hosts of this cloud provider, for example `AWS`, `AZURE` or `GOOGLE`"),
+			"cloud_provider_region": optional("Only hosts in this cloud provider region"),
+			"address_cidr": schema.StringAttribute{
+				MarkdownDescription: "Only hosts with an address in this CIDR block, for example `10.0.0.0/16`",
+				Optional:            true,
+			},
+			"host_type": optional("Only hosts of this type"),
+			"service":   optional("Only hosts offering this service, for example `SSH`, `RDP`, `VNC`, `WEB` or `DB`"),
+			"query":     optional("Free-text search over the hosts"),
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of hosts returned. All matching hosts are returned when unset; when more hosts match, a warning is reported.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching hosts",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"hosts": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching hosts",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                    computed("Host ID"),
+						"common_name":           computed("Host common name"),
+						"external_id":           computed("External ID"),
+						"instance_id":           computed("Cloud instance ID"),
+						"access_group_id":       computed("Access group of the host"),
+						"source_id":             computed("Host source the host was imported from"),
+						"cloud_provider":        computed("Cloud provider"),
+						"cloud_provider_region": computed("Cloud provider region"),
+						"host_type":             computed("Host type"),
+						"disabled":              computed("Disabled state of the host"),
+						"addresses": schema.ListAttribute{
+							MarkdownDescription: "Host addresses",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "Host tags",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"services": schema.ListNestedAttribute{
+							MarkdownDescription: "Host services",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"service": computed("Service type"),
+									"address": computed("Service address"),
+									"port": schema.Int64Attribute{
+										MarkdownDescription: "Service port",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var cidr types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("address_cidr"), &cidr)...)
+	if resp.Diagnostics.HasError() || cidr.IsNull() || cidr.IsUnknown() {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(cidr.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("address_cidr"), "Invalid CIDR",
+			fmt.Sprintf("address_cidr must be a CIDR block such as \"10.0.0.0/16\": %s", err))
+	}
+}
+
+func (d *HostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating hoststore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = hoststore.New(*connector)
+}
+
+func (d *HostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	search := &hoststore.HostSearch{
+		Keywords: data.Query.ValueString(),
+		SourceID: data.SourceID.ValueString(),
+	}
+	for _, tag := range data.Tags {
+		search.Tags = append(search.Tags, tag.ValueString())
+	}
+	if v := data.AccessGroupID.ValueString(); v != "" {
+		search.AccessGroupIDs = []string{v}
+	}
+	if v := data.CloudProvider.ValueString(); v != "" {
+		search.CloudProviders = []string{v}
+	}
+	if v := data.CloudProviderRegion.ValueString(); v != "" {
+		search.CloudProviderRegions = []string{v}
+	}
+	if v := data.HostType.ValueString(); v != "" {
+		search.HostType = []string{v}
+	}
+	if v := data.Service.ValueString(); v != "" {
+		search.Service = []string{v}
+	}
+
+	// The search API matches exact addresses only; CIDR blocks are matched here.
+	var network *net.IPNet
+	if v := data.AddressCIDR.ValueString(); v != "" {
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("address_cidr"), "Invalid CIDR", err.Error())
+			return
+		}
+		network = ipNet
+	}
+
+	// Zero means no limit.
+	maxResults := int(data.MaxResults.ValueInt64())
+
+	data.IDs = []types.String{}
+	data.Hosts = []HostsItemModel{}
+	truncated := false
+	for offset := 0; !truncated; offset += hostsPageSize {
+		page, err := d.client.SearchHosts(search, filters.Paging(offset, hostsPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search hosts, got error: %s", err))
+			return
+		}
+
+		for _, host := range page.Items {
+			if network != nil && !hostInNetwork(host, network) {
+				continue
+			}
+			if maxResults > 0 && len(data.Hosts) == maxResults {
+				truncated = true
+				break
+			}
+			data.IDs = append(data.IDs, types.StringValue(host.ID))
+			data.Hosts = append(data.Hosts, flattenHostsItem(host))
+		}
+
+		if len(page.Items) < hostsPageSize {
+			break
+		}
+	}
+	if truncated {
+		addTruncatedWarning(&resp.Diagnostics, "hosts", maxResults)
+	}
+
+	tags := make([]string, len(data.Tags))
+	for i, tag := range data.Tags {
+		tags[i] = tag.ValueString()
+	}
+	data.ID = types.StringValue(strings.Join([]string{
+		strings.Join(tags, ","),
+		data.AccessGroupID.ValueString(),
+		data.SourceID.ValueString(),
+		data.CloudProvider.ValueString(),
+		data.CloudProviderRegion.ValueString(),
+		data.AddressCIDR.ValueString(),
+		data.HostType.ValueString(),
+		data.Service.ValueString(),
+		data.Query.ValueString(),
+	}, "/"))
+
+	tflog.Debug(ctx, "Storing hosts into the state", map[string]interface{}{
+		"count": len(data.Hosts),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hostInNetwork reports whether any host or service address lies in network.
+// Addresses that are host names rather than IPs never match.
+func hostInNetwork(host hoststore.Host, network *net.IPNet) bool {
+	addresses := append([]string{}, host.Addresses...)
+	for _, service := range host.Services {
+		addresses = append(addresses, service.Address)
+	}
+	for _, address := range addresses {
+		if ip := net.ParseIP(address); ip != nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenHostsItem(host hoststore.Host) HostsItemModel {
+	item := HostsItemModel{
+		ID:                  types.StringValue(host.ID),
+		CommonName:          types.StringValue(host.CommonName),
+		ExternalID:          types.StringValue(host.ExternalID),
+		InstanceID:          types.StringValue(host.InstanceID),
+		AccessGroupID:       types.StringValue(host.AccessGroupID),
+		SourceID:            types.StringValue(host.SourceID),
+		CloudProvider:       types.StringValue(host.CloudProvider),
+		CloudProviderRegion: types.StringValue(host.CloudProviderRegion),
+		HostType:            types.StringValue(host.HostType),
+		Disabled:            types.StringValue(host.Disabled),
+		Addresses:           []types.String{},
+		Tags:                []types.String{},
+		Services:            []HostsItemServiceModel{},
+	}
+	for _, address := range host.Addresses {
+		item.Addresses = append(item.Addresses, types.StringValue(address))
+	}
+	for _, tag := range host.Tags {
+		item.Tags = append(item.Tags, types.StringValue(tag))
+	}
+	for _, service := range host.Services {
+		item.Services = append(item.Services, HostsItemServiceModel{
+			Service: types.StringValue(service.Service),
+			Address: types.StringValue(service.Address),
+			Port:    types.Int64Value(int64(service.Port)),
+		})
+	}
+	return item
+}
+
+// addTruncatedWarning reports that a list data source returned only the
+// first maxResults of the matching objects.
+func addTruncatedWarning(diags *diag.Diagnostics, objects string, maxResults int) {
+	diags.AddAttributeWarning(
+		path.Root("max_results"),
+		"Results Truncated",
+		fmt.Sprintf("More than %d %s match, only the first %d are returned. Raise max_results or narrow the filters to see all of them.", maxResults, objects, maxResults),
+	)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHostsDataSource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, "abcdefghijklmnopqrstuvwxyz0123456789")
+	tag := "tf-acc-hosts-" + suffix
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_access_group" "ag" {
+  name = "Default"
+}
+
+resource "privx_host" "test" {
+  count = 2
+
+  common_name     = "%[1]s-${count.index}"
+  addresses       = ["198.51.100.${count.index + 10}"]
+  access_group_id = data.privx_access_group.ag.id
+  tags            = [%[1]q]
+
+  services = [{
+    service = "SSH"
+    address = "198.51.100.${count.index + 10}"
+    port    = 22
+  }]
+}
+
+data "privx_hosts" "tagged" {
+  tags    = [%[1]q]
+  service = "SSH"
+
+  depends_on = [privx_host.test]
+}
+
+data "privx_hosts" "cidr" {
+  tags         = [%[1]q]
+  address_cidr = "198.51.100.10/32"
+
+  depends_on = [privx_host.test]
+}
+`, tag)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_hosts.tagged", "hosts.#", "2"),
+					resource.TestCheckResourceAttr("data.privx_hosts.tagged", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.privx_hosts.cidr", "hosts.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_hosts.cidr", "hosts.0.id", "privx_host.test.0", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,457 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IdentityProviderResource{}
+var _ resource.ResourceWithImportState = &IdentityProviderResource{}
+
+const (
+	identityProviderKeyMethodStatic = "static"
+	identityProviderKeyMethodX5U    = "x5u"
+)
+
+func NewIdentityProviderResource() resource.Resource {
+	return &IdentityProviderResource{}
+}
+
+// IdentityProviderResource defines the resource implementation.
+type IdentityProviderResource struct {
+	client *rolestore.RoleStore
+}
+
+// IdentityProviderCustomAttributeModel is a token claim validated on login.
+type IdentityProviderCustomAttributeModel struct {
+	FieldName     types.String `tfsdk:"field_name"`
+	Type          types.String `tfsdk:"type"`
+	ExpectedValue types.String `tfsdk:"expected_value"`
+	Start         types.String `tfsdk:"start"`
+	End           types.String `tfsdk:"end"`
+}
+
+// IdentityProviderPublicKeyModel is a token signing key of the provider.
+type IdentityProviderPublicKeyModel struct {
+	KeyID     types.String `tfsdk:"key_id"`
+	Comment   types.String `tfsdk:"comment"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+// IdentityProviderResourceModel describes the resource data model.
+type IdentityProviderResourceModel struct {
+	ID                            types.String                           `tfsdk:"id"`
+	Name                          types.String                           `tfsdk:"name"`
+	TokenType                     types.String                           `tfsdk:"token_type"`
+	JWTIssuer                     types.String                           `tfsdk:"jwt_issuer"`
+	JWTAudience                   types.String                           `tfsdk:"jwt_audience"`
+	JWTSubjectType                types.String                           `tfsdk:"jwt_subject_type"`
+	JWTSubjectDNUsernameAttribute types.String                           `tfsdk:"jwt_subject_dn_username_attribute"`
+	CustomAttributes              []IdentityProviderCustomAttributeModel `tfsdk:"custom_attributes"`
+	PublicKeyMethod               types.String                           `tfsdk:"public_key_method"`
+	PublicKeys                    []IdentityProviderPublicKeyModel       `tfsdk:"public_keys"`
+	X5uTrustAnchor                types.String                           `tfsdk:"x5u_trust_anchor"`
+	X5uTLSTrustAnchor             types.String                           `tfsdk:"x5u_tls_trust_anchor"`
+	X5uPrefix                     types.String                           `tfsdk:"x5u_prefix"`
+	UsersDirectory                types.String                           `tfsdk:"users_directory"`
+	Enabled                       types.Bool                             `tfsdk:"enabled"`
+	Created                       types.String                           `tfsdk:"created"`
+	Author                        types.String                           `tfsdk:"author"`
+}
+
+func (r *IdentityProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_provider"
+}
+
+func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Identity provider resource. Configures an external identity provider whose signed tokens " +
+			"(for example OIDC ID tokens) PrivX accepts for login. The role-store API validates tokens against the " +
+			"configured issuer, audience and public keys. The API has no client ID, client secret or other credential fields, " +
+			"so this resource cannot manage client credentials and none of its attributes is sensitive. " +
+			"OIDC logins that need a client secret are configured with `privx_source`, whose `oidc_connection.client_secret` is sensitive.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identity provider ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the identity provider",
+				Required:            true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the login token issued by the provider",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("JWT"),
+			},
+			"jwt_issuer": schema.StringAttribute{
+				MarkdownDescription: "Expected `iss` claim, usually the issuer URL of the provider",
+				Required:            true,
+			},
+			"jwt_audience": schema.StringAttribute{
+				MarkdownDescription: "Expected `aud` claim, usually the client ID registered for PrivX",
+				Required:            true,
+			},
+			"jwt_subject_type": schema.StringAttribute{
+				MarkdownDescription: "How the `sub` claim is mapped to a PrivX user",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"jwt_subject_dn_username_attribute": schema.StringAttribute{
+				MarkdownDescription: "Attribute of a distinguished name subject holding the username",
+				Optional:            true,
+			},
+			"custom_attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "Additional token claims that must match for the login to be accepted",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field_name": schema.StringAttribute{
+							MarkdownDescription: "Name of the claim",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Validation type of the claim",
+							Required:            true,
+						},
+						"expected_value": schema.StringAttribute{
+							MarkdownDescription: "Expected value of the claim",
+							Optional:            true,
+						},
+						"start": schema.StringAttribute{
+							MarkdownDescription: "Start of the accepted range",
+							Optional:            true,
+						},
+						"end": schema.StringAttribute{
+							MarkdownDescription: "End of the accepted range",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"public_key_method": schema.StringAttribute{
+				MarkdownDescription: "How token signing keys are obtained: `static` uses `public_keys`, `x5u` fetches them from the `x5u` header URL",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(identityProviderKeyMethodStatic),
+				Validators: []validator.String{
+					stringvalidator.OneOf(identityProviderKeyMethodStatic, identityProviderKeyMethodX5U),
+				},
+			},
+			"public_keys": schema.ListNestedAttribute{
+				MarkdownDescription: "Token signing keys, used with the `static` key method",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_id": schema.StringAttribute{
+							MarkdownDescription: "Key ID matched against the `kid` token header",
+							Required:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "Optional comment",
+							Optional:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "PEM encoded public key",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"x5u_trust_anchor": schema.StringAttribute{
+				MarkdownDescription: "PEM trust anchor for certificates fetched with the `x5u` key method",
+				Optional:            true,
+			},
+			"x5u_tls_trust_anchor": schema.StringAttribute{
+				MarkdownDescription: "PEM trust anchor for the TLS connection used to fetch `x5u` certificates",
+				Optional:            true,
+			},
+			"x5u_prefix": schema.StringAttribute{
+				MarkdownDescription: "Required URL prefix of the `x5u` header",
+				Optional:            true,
+			},
+			"users_directory": schema.StringAttribute{
+				MarkdownDescription: "ID of the user source the token subjects are looked up from",
+				Optional:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether logins through the provider are accepted",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: "Creation time",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"author": schema.StringAttribute{
+				MarkdownDescription: "ID of the user who created the identity provider",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IdentityProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = rolestore.New(*connector)
+}
+
+func (r *IdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IdentityProviderResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider := expandIdentityProvider(&data)
+
+	tflog.Debug(ctx, "Creating identity provider", map[string]interface{}{
+		"name": provider.Name,
+	})
+
+	identifier, err := r.client.CreateIdentityProvider(provider)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	remote, err := r.client.GetIdentityProvider(identifier.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read identity provider after create, got error: %s", err))
+		return
+	}
+
+	flattenIdentityProvider(remote, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IdentityProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IdentityProviderResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.client.GetIdentityProvider(data.ID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read identity provider, got error: %s", err))
+		return
+	}
+
+	flattenIdentityProvider(remote, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IdentityProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IdentityProviderResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	provider := expandIdentityProvider(&data)
+	provider.ID = data.ID.ValueString()
+
+	if err := r.client.UpdateIdentityProvider(data.ID.ValueString(), provider); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update identity provider, got error: %s", err))
+		return
+	}
+
+	remote, err := r.client.GetIdentityProvider(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read identity provider after update, got error: %s", err))
+		return
+	}
+
+	flattenIdentityProvider(remote, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *IdentityProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IdentityProviderResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteIdentityProvider(data.ID.ValueString()); err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete identity provider, got error: %s", err))
+		return
+	}
+}
+
+func (r *IdentityProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func expandIdentityProvider(data *IdentityProviderResourceModel) *rolestore.IdentityProvider {
+	provider := &rolestore.IdentityProvider{
+		Name:                          data.Name.ValueString(),
+		TokenType:                     data.TokenType.ValueString(),
+		JWTIssuer:                     data.JWTIssuer.ValueString(),
+		JWTAudience:                   data.JWTAudience.ValueString(),
+		JWTSubjectType:                data.JWTSubjectType.ValueString(),
+		JWTSubjectDNUsernameAttribute: data.JWTSubjectDNUsernameAttribute.ValueString(),
+		PublicKeyMethod:               data.PublicKeyMethod.ValueString(),
+		X5uTrustAnchor:                data.X5uTrustAnchor.ValueString(),
+		X5uTLSTrustAnchor:             data.X5uTLSTrustAnchor.ValueString(),
+		X5uPrefix:                     data.X5uPrefix.ValueString(),
+		UsersDirectory:                data.UsersDirectory.ValueString(),
+		Enabled:                       data.Enabled.ValueBool(),
+	}
+
+	for _, attr := range data.CustomAttributes {
+		provider.CustomAttributes = append(provider.CustomAttributes, rolestore.CustomAttributeValidation{
+			FieldName:     attr.FieldName.ValueString(),
+			Type:          attr.Type.ValueString(),
+			ExpectedValue: attr.ExpectedValue.ValueString(),
+			Start:         attr.Start.ValueString(),
+			End:           attr.End.ValueString(),
+		})
+	}
+
+	for _, key := range data.PublicKeys {
+		provider.PublicKeys = append(provider.PublicKeys, rolestore.PublicKey{
+			KeyID:     key.KeyID.ValueString(),
+			Comment:   key.Comment.ValueString(),
+			PublicKey: key.PublicKey.ValueString(),
+		})
+	}
+
+	return provider
+}
+
+// flattenIdentityProvider copies the server view into data. Optional strings
+// the server reports as empty stay null when they were not configured.
+func flattenIdentityProvider(remote *rolestore.IdentityProvider, data *IdentityProviderResourceModel) {
+	data.ID = types.StringValue(remote.ID)
+	data.Name = types.StringValue(remote.Name)
+	data.TokenType = types.StringValue(remote.TokenType)
+	data.JWTIssuer = types.StringValue(remote.JWTIssuer)
+	data.JWTAudience = types.StringValue(remote.JWTAudience)
+	data.JWTSubjectType = types.StringValue(remote.JWTSubjectType)
+	data.JWTSubjectDNUsernameAttribute = identityProviderString(remote.JWTSubjectDNUsernameAttribute, data.JWTSubjectDNUsernameAttribute)
+	data.PublicKeyMethod = types.StringValue(remote.PublicKeyMethod)
+	data.X5uTrustAnchor = identityProviderString(remote.X5uTrustAnchor, data.X5uTrustAnchor)
+	data.X5uTLSTrustAnchor = identityProviderString(remote.X5uTLSTrustAnchor, data.X5uTLSTrustAnchor)
+	data.X5uPrefix = identityProviderString(remote.X5uPrefix, data.X5uPrefix)
+	data.UsersDirectory = identityProviderString(remote.UsersDirectory, data.UsersDirectory)
+	data.Enabled = types.BoolValue(remote.Enabled)
+	data.Created = types.StringValue(remote.Created)
+	data.Author = types.StringValue(remote.Author)
+
+	prior := data.CustomAttributes
+	if len(remote.CustomAttributes) == 0 {
+		if len(prior) > 0 {
+			data.CustomAttributes = []IdentityProviderCustomAttributeModel{}
+		}
+	} else {
+		data.CustomAttributes = make([]IdentityProviderCustomAttributeModel, 0, len(remote.CustomAttributes))
+		for i, attr := range remote.CustomAttributes {
+			var was IdentityProviderCustomAttributeModel
+			if i < len(prior) {
+				was = prior[i]
+			}
+			data.CustomAttributes = append(data.CustomAttributes, IdentityProviderCustomAttributeModel{
+				FieldName:     types.StringValue(attr.FieldName),
+				Type:          types.StringValue(attr.Type),
+				ExpectedValue: identityProviderString(attr.ExpectedValue, was.ExpectedValue),
+				Start:         identityProviderString(attr.Start, was.Start),
+				End:           identityProviderString(attr.End, was.End),
+			})
+		}
+	}
+
+	priorKeys := data.PublicKeys
+	if len(remote.PublicKeys) == 0 {
+		if len(priorKeys) > 0 {
+			data.PublicKeys = []IdentityProviderPublicKeyModel{}
+		}
+	} else {
+		data.PublicKeys = make([]IdentityProviderPublicKeyModel, 0, len(remote.PublicKeys))
+		for i, key := range remote.PublicKeys {
+			var was IdentityProviderPublicKeyModel
+			if i < len(priorKeys) {
+				was = priorKeys[i]
+			}
+			data.PublicKeys = append(data.PublicKeys, IdentityProviderPublicKeyModel{
+				KeyID:     types.StringValue(key.KeyID),
+				Comment:   identityProviderString(key.Comment, was.Comment),
+				PublicKey: types.StringValue(key.PublicKey),
+			})
+		}
+	}
+}
+
+func identityProviderString(value string, prior types.String) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIdentityProviderResource(t *testing.T) {
+	name := "tf-acc-idp-" + acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_identity_provider.test"
+	publicKey := testAccECDSAPublicKeyPEM(t)
+
+	cfg1 := testAccIdentityProviderConfig(name, publicKey, true)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccIdentityProviderConfig(name, publicKey, false)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "jwt_issuer", "https://login.example.com/"),
+					resource.TestCheckResourceAttr(resourceName, "public_keys.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+
+			// UPDATE: disable
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccECDSAPublicKeyPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ecdsa key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal ecdsa public key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func testAccIdentityProviderConfig(name, publicKey string, enabled bool) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_identity_provider" "test" {
+  name         = %[1]q
+  jwt_issuer   = "https://login.example.com/"
+  jwt_audience = "privx"
+
+  public_keys = [
+    {
+      key_id     = "tf-acc"
+      public_key = %[2]q
+    },
+  ]
+
+  enabled = %[3]t
+}
+`, name, publicKey, enabled)
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/licensemanager"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LicenseDataSource{}
+
+// licenseID is the synthetic ID used for the single PrivX license object.
+const licenseID = "privx-license"
+
+func NewLicenseDataSource() datasource.DataSource {
+	return &LicenseDataSource{}
+}
+
+// LicenseDataSource defines the data source implementation.
+type LicenseDataSource struct {
+	client *licensemanager.LicenseManager
+}
+
+// LicenseDataSourceModel describes the data source data model.
+type LicenseDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Status          types.String `tfsdk:"status"`
+	LicenseType     types.String `tfsdk:"license_type"`
+	Expires         types.String `tfsdk:"expires"`
+	MaxUsers        types.Int64  `tfsdk:"max_users"`
+	CurrentUsers    types.Int64  `tfsdk:"current_users"`
+	MaxHosts        types.Int64  `tfsdk:"max_hosts"`
+	CurrentHosts    types.Int64  `tfsdk:"current_hosts"`
+	StatisticsOptin types.Bool   `tfsdk:"statistics_optin"`
+	Features        types.Map    `tfsdk:"features"`
+	LicenseJSON     types.String `tfsdk:"license_json"`
+}
+
+func (d *LicenseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license"
+}
+
+func (d *LicenseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "License data source. Reads the PrivX license status, expiry, seat usage and feature flags.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Synthetic license ID",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "License status reported by PrivX",
+				Computed:            true,
+			},
+			"license_type": schema.StringAttribute{
+				MarkdownDescription: "License type reported by PrivX",
+				Computed:            true,
+			},
+			"expires": schema.StringAttribute{
+				MarkdownDescription: "License expiry time as reported by PrivX, empty if the license does not expire",
+				Computed:            true,
+			},
+			"max_users": schema.Int64Attribute{
+				MarkdownDescription: "Number of licensed users",
+				Computed:            true,
+			},
+			"current_users": schema.Int64Attribute{
+				MarkdownDescription: "Number of users currently consuming a license seat",
+				Computed:            true,
+			},
+			"max_hosts": schema.Int64Attribute{
+				MarkdownDescription: "Number of licensed hosts",
+				Computed:            true,
+			},
+			"current_hosts": schema.Int64Attribute{
+				MarkdownDescription: "Number of hosts currently consuming a license seat",
+				Computed:            true,
+			},
+			"statistics_optin": schema.BoolAttribute{
+				MarkdownDescription: "Whether license statistics are shared with SSH",
+				Computed:            true,
+			},
+			"features": schema.MapAttribute{
+				ElementType:         types.BoolType,
+				MarkdownDescription: "Licensed feature flags keyed by feature name",
+				Computed:            true,
+			},
+			"license_json": schema.StringAttribute{
+				MarkdownDescription: "Full license document returned by PrivX, JSON encoded, with the license code removed",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *LicenseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating licensemanager", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = licensemanager.New(*connector)
+}
+
+func (d *LicenseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LicenseDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	license, err := d.client.GetLicense()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read license, got error: %s", err))
+		return
+	}
+
+	info, diags := flattenLicense(ctx, license)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(licenseID)
+	data.Status = info.Status
+	data.LicenseType = info.LicenseType
+	data.Expires = info.Expires
+	data.MaxUsers = info.MaxUsers
+	data.CurrentUsers = info.CurrentUsers
+	data.MaxHosts = info.MaxHosts
+	data.CurrentHosts = info.CurrentHosts
+	data.StatisticsOptin = info.StatisticsOptin
+	data.Features = info.Features
+	data.LicenseJSON = info.LicenseJSON
+
+	tflog.Debug(ctx, "Storing license into the state", map[string]interface{}{
+		"status":  data.Status.ValueString(),
+		"expires": data.Expires.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// licenseInfo is the flattened license document shared by the license
+// resource and data source.
+type licenseInfo struct {
+	Status          types.String
+	LicenseType     types.String
+	Expires         types.String
+	MaxUsers        types.Int64
+	CurrentUsers    types.Int64
+	MaxHosts        types.Int64
+	CurrentHosts    types.Int64
+	StatisticsOptin types.Bool
+	Features        types.Map
+	LicenseJSON     types.String
+}
+
+// licenseDocument is the license returned by GET
+// /license-manager/api/v1/license. The SDK returns it as an untyped map.
+// ExpiryDate is omitted for licenses that do not expire; every other field
+// is required.
+type licenseDocument struct {
+	Status       *string          `json:"status"`
+	Type         *string          `json:"type"`
+	ExpiryDate   *string          `json:"expiry_date"`
+	MaxUsers     *int64           `json:"max_users"`
+	CurrentUsers *int64           `json:"current_users"`
+	MaxHosts     *int64           `json:"max_hosts"`
+	CurrentHosts *int64           `json:"current_hosts"`
+	Optin        *bool            `json:"optin"`
+	Features     *map[string]bool `json:"features"`
+}
+
+// decodeLicense decodes the license document and reports the required
+// fields it is missing.
+func decodeLicense(license map[string]interface{}) (licenseDocument, error) {
+	var doc licenseDocument
+
+	raw, err := json.Marshal(license)
+	if err != nil {
+		return doc, err
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return doc, err
+	}
+
+	var missing []string
+	for name, present := range map[string]bool{
+		"status":        doc.Status != nil,
+		"type":          doc.Type != nil,
+		"max_users":     doc.MaxUsers != nil,
+		"current_users": doc.CurrentUsers != nil,
+		"max_hosts":     doc.MaxHosts != nil,
+		"current_hosts": doc.CurrentHosts != nil,
+		"optin":         doc.Optin != nil,
+		"features":      doc.Features != nil,
+	} {
+		if !present {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return doc, fmt.Errorf("license document is missing %s", strings.Join(missing, ", "))
+	}
+	return doc, nil
+}
+
+func flattenLicense(ctx context.Context, license map[string]interface{}) (licenseInfo, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	doc, err := decodeLicense(license)
+	if err != nil {
+		diags.AddError("Unable to decode license", err.Error())
+		return licenseInfo{}, diags
+	}
+
+	info := licenseInfo{
+		Status:          types.StringValue(*doc.Status),
+		LicenseType:     types.StringValue(*doc.Type),
+		Expires:         types.StringValue(""),
+		MaxUsers:        types.Int64Value(*doc.MaxUsers),
+		CurrentUsers:    types.Int64Value(*doc.CurrentUsers),
+		MaxHosts:        types.Int64Value(*doc.MaxHosts),
+		CurrentHosts:    types.Int64Value(*doc.CurrentHosts),
+		StatisticsOptin: types.BoolValue(*doc.Optin),
+	}
+	if doc.ExpiryDate != nil {
+		info.Expires = types.StringValue(*doc.ExpiryDate)
+	}
+
+	features, d := types.MapValueFrom(ctx, types.BoolType, *doc.Features)
+	diags.Append(d...)
+	info.Features = features
+
+	redacted := make(map[string]interface{}, len(license))
+	for k, v := range license {
+		redacted[k] = v
+	}
+	delete(redacted, "license_code")
+	raw, err := json.Marshal(redacted)
+	if err != nil {
+		diags.AddError("Unable to encode license", err.Error())
+		return info, diags
+	}
+	info.LicenseJSON = types.StringValue(string(raw))
+
+	return info, diags
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLicenseDataSource(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+
+	cfg := `
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_license" "test" {}
+`
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_license.test", "id", licenseID),
+					resource.TestCheckResourceAttrSet("data.privx_license.test", "status"),
+					resource.TestCheckResourceAttrSet("data.privx_license.test", "max_users"),
+					resource.TestCheckResourceAttrSet("data.privx_license.test", "license_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLicenseResource(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	testAccLicensePreCheck(t)
+
+	code := os.Getenv("PRIVX_LICENSE_CODE")
+	if code == "" {
+		t.Skip("PRIVX_LICENSE_CODE not set")
+	}
+	resourceName := "privx_license.test"
+
+	cfg1 := testAccLicenseConfig(code, false)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccLicenseConfig(code, true)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", licenseID),
+					resource.TestCheckResourceAttr(resourceName, "statistics_optin", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+				),
+			},
+
+			// UPDATE opt-in with refresh
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "statistics_optin", "true"),
+					resource.TestCheckResourceAttrPair("data.privx_license.test", "status", resourceName, "status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLicenseConfig(code string, optin bool) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_license" "test" {
+  license_code      = %q
+  statistics_optin  = %t
+  refresh_on_update = true
+  refresh_triggers = {
+    optin = "%t"
+  }
+}
+
+data "privx_license" "test" {
+  depends_on = [privx_license.test]
+}
+`, code, optin, optin)
+}
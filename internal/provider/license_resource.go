@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/licensemanager"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LicenseResource{}
+
+func NewLicenseResource() resource.Resource {
+	return &LicenseResource{}
+}
+
+// LicenseResource defines the resource implementation.
+type LicenseResource struct {
+	client *licensemanager.LicenseManager
+}
+
+// LicenseResourceModel contains PrivX license information.
+type LicenseResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	LicenseCode         types.String `tfsdk:"license_code"`
+	StatisticsOptin     types.Bool   `tfsdk:"statistics_optin"`
+	RefreshOnUpdate     types.Bool   `tfsdk:"refresh_on_update"`
+	RefreshTriggers     types.Map    `tfsdk:"refresh_triggers"`
+	DeactivateOnDestroy types.Bool   `tfsdk:"deactivate_on_destroy"`
+	Status              types.String `tfsdk:"status"`
+	LicenseType         types.String `tfsdk:"license_type"`
+	Expires             types.String `tfsdk:"expires"`
+	MaxUsers            types.Int64  `tfsdk:"max_users"`
+	CurrentUsers        types.Int64  `tfsdk:"current_users"`
+	MaxHosts            types.Int64  `tfsdk:"max_hosts"`
+	CurrentHosts        types.Int64  `tfsdk:"current_hosts"`
+	Features            types.Map    `tfsdk:"features"`
+}
+
+func (r *LicenseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_license"
+}
+
+func (r *LicenseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "License resource. Uploads a PrivX license code and keeps the license information refreshed. There is only one license per PrivX deployment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Synthetic license ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"license_code": schema.StringAttribute{
+				MarkdownDescription: "PrivX license code (write-only, cannot be read back)",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"statistics_optin": schema.BoolAttribute{
+				MarkdownDescription: "Share license statistics with SSH",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"refresh_on_update": schema.BoolAttribute{
+				MarkdownDescription: "Refresh the license information from the license server whenever the resource is updated. An update only happens when another argument changes; use `refresh_triggers` to force one",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"refresh_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that refresh the license information from the license server when they change, for example a timestamp",
+				Optional:            true,
+			},
+			"deactivate_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Deactivate the license when the resource is destroyed. When false, destroy only removes the license from Terraform state.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "License status reported by PrivX",
+				Computed:            true,
+			},
+			"license_type": schema.StringAttribute{
+				MarkdownDescription: "License type reported by PrivX",
+				Computed:            true,
+			},
+			"expires": schema.StringAttribute{
+				MarkdownDescription: "License expiry time as reported by PrivX, empty if the license does not expire",
+				Computed:            true,
+			},
+			"max_users": schema.Int64Attribute{
+				MarkdownDescription: "Number of licensed users",
+				Computed:            true,
+			},
+			"current_users": schema.Int64Attribute{
+				MarkdownDescription: "Number of users currently consuming a license seat",
+				Computed:            true,
+			},
+			"max_hosts": schema.Int64Attribute{
+				MarkdownDescription: "Number of licensed hosts",
+				Computed:            true,
+			},
+			"current_hosts": schema.Int64Attribute{
+				MarkdownDescription: "Number of hosts currently consuming a license seat",
+				Computed:            true,
+			},
+			"features": schema.MapAttribute{
+				ElementType:         types.BoolType,
+				MarkdownDescription: "Licensed feature flags keyed by feature name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *LicenseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating licensemanager", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = licensemanager.New(*connector)
+}
+
+func (r *LicenseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data LicenseResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetLicense(data.LicenseCode.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set license, got error: %s", err))
+		return
+	}
+
+	err := r.client.SetLicenseStatistics(licensemanager.LicenseStatistics{Optin: data.StatisticsOptin.ValueBool()})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set license statistics opt-in, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(licenseID)
+	resp.Diagnostics.Append(r.readLicense(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "created license resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LicenseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data LicenseResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The license code cannot be read back; only the derived license
+	// information and the statistics opt-in are refreshed.
+	resp.Diagnostics.Append(r.readLicense(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LicenseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan LicenseResourceModel
+	var state LicenseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.LicenseCode.Equal(state.LicenseCode) {
+		if err := r.client.SetLicense(plan.LicenseCode.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set license, got error: %s", err))
+			return
+		}
+	}
+
+	if !plan.StatisticsOptin.Equal(state.StatisticsOptin) {
+		err := r.client.SetLicenseStatistics(licensemanager.LicenseStatistics{Optin: plan.StatisticsOptin.ValueBool()})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set license statistics opt-in, got error: %s", err))
+			return
+		}
+	}
+
+	if plan.RefreshOnUpdate.ValueBool() || !plan.RefreshTriggers.Equal(state.RefreshTriggers) {
+		if _, err := r.client.RefreshLicense(); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to refresh license, got error: %s", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.readLicense(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *LicenseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data LicenseResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DeactivateOnDestroy.ValueBool() {
+		tflog.Info(ctx, "deactivate_on_destroy is false, leaving the PrivX license active")
+		return
+	}
+
+	if err := r.client.DeactivateLicense(); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deactivate license, got error: %s", err))
+		return
+	}
+}
+
+// readLicense fetches the license document and copies the derived fields and
+// the statistics opt-in into the model.
+func (r *LicenseResource) readLicense(ctx context.Context, data *LicenseResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	license, err := r.client.GetLicense()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read license, got error: %s", err))
+		return diags
+	}
+
+	info, d := flattenLicense(ctx, license)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.StatisticsOptin = info.StatisticsOptin
+	data.Status = info.Status
+	data.LicenseType = info.LicenseType
+	data.Expires = info.Expires
+	data.MaxUsers = info.MaxUsers
+	data.CurrentUsers = info.CurrentUsers
+	data.MaxHosts = info.MaxHosts
+	data.CurrentHosts = info.CurrentHosts
+	data.Features = info.Features
+
+	return diags
+}
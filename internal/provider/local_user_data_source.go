@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LocalUserDataSource{}
+
+func NewLocalUserDataSource() datasource.DataSource {
+	return &LocalUserDataSource{}
+}
+
+// LocalUserDataSource defines the data source implementation.
+type LocalUserDataSource struct {
+	client     *userstore.UserStore
+	roleClient *rolestore.RoleStore
+}
+
+// LocalUserDataSourceModel describes the data source data model.
+type LocalUserDataSourceModel struct {
+	ID                     types.String   `tfsdk:"id"`
+	Username               types.String   `tfsdk:"username"`
+	FullName               types.String   `tfsdk:"full_name"`
+	FirstName              types.String   `tfsdk:"first_name"`
+	LastName               types.String   `tfsdk:"last_name"`
+	Email                  types.String   `tfsdk:"email"`
+	JobTitle               types.String   `tfsdk:"job_title"`
+	Department             types.String   `tfsdk:"department"`
+	Company                types.String   `tfsdk:"company"`
+	Telephone              types.String   `tfsdk:"telephone"`
+	Locale                 types.String   `tfsdk:"locale"`
+	UnixAccount            types.String   `tfsdk:"unix_account"`
+	WindowsAccount         types.String   `tfsdk:"windows_account"`
+	PasswordChangeRequired types.Bool     `tfsdk:"password_change_required"`
+	PasswordCreated        types.String   `tfsdk:"password_created"`
+	Tags                   []types.String `tfsdk:"tags"`
+	Roles                  []types.String `tfsdk:"roles"`
+	RoleNames              []types.String `tfsdk:"role_names"`
+}
+
+func (d *LocalUserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_user"
+}
+
+func (d *LocalUserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Local user data source. Looks up a PrivX local user by username.",
+		Attributes: map[string]schema.Attribute{
+			"id": computed("Local user ID"),
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the local user",
+				Required:            true,
+			},
+			"full_name":       computed("Full name"),
+			"first_name":      computed("First name"),
+			"last_name":       computed("Last name"),
+			"email":           computed("Email address"),
+			"job_title":       computed("Job title"),
+			"department":      computed("Department"),
+			"company":         computed("Company"),
+			"telephone":       computed("Telephone number"),
+			"locale":          computed("Locale"),
+			"unix_account":    computed("Unix account name"),
+			"windows_account": computed("Windows account name"),
+			"password_change_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user must change the password at next login",
+				Computed:            true,
+			},
+			"password_created": computed("Time the current password was set"),
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Tags of the user",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "IDs of the roles explicitly granted to the user",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"role_names": schema.ListAttribute{
+				MarkdownDescription: "Names of the roles explicitly granted to the user",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *LocalUserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = userstore.New(*connector)
+	d.roleClient = rolestore.New(*connector)
+}
+
+func (d *LocalUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LocalUserDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	username := data.Username.ValueString()
+	users, err := d.client.GetUsers(filters.SetStructParams(userstore.LocalUserParams{Username: username}))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search local users, got error: %s", err))
+		return
+	}
+
+	// The username filter is not guaranteed to be an exact match.
+	var user *userstore.LocalUser
+	for i := range users.Items {
+		if users.Items[i].Principal == username {
+			user = &users.Items[i]
+			break
+		}
+	}
+	if user == nil {
+		resp.Diagnostics.AddError("Local User Not Found", fmt.Sprintf("No local user with username %q", username))
+		return
+	}
+
+	data.ID = types.StringValue(user.ID)
+	data.FullName = types.StringValue(user.FullName)
+	data.FirstName = types.StringValue(user.FirstName)
+	data.LastName = types.StringValue(user.LastName)
+	data.Email = types.StringValue(user.Email)
+	data.JobTitle = types.StringValue(user.JobTitle)
+	data.Department = types.StringValue(user.Department)
+	data.Company = types.StringValue(user.Company)
+	data.Telephone = types.StringValue(user.Telephone)
+	data.Locale = types.StringValue(user.Locale)
+	data.UnixAccount = types.StringValue(user.UnixAccount)
+	data.WindowsAccount = types.StringValue(user.WindowsAccount)
+	data.PasswordChangeRequired = types.BoolValue(user.PasswordChangeRequired)
+	data.PasswordCreated = types.StringValue(user.Password.Created)
+
+	data.Tags = []types.String{}
+	for _, tag := range user.Tags {
+		data.Tags = append(data.Tags, types.StringValue(tag))
+	}
+
+	roles, err := getExplicitUserRoles(d.roleClient, user.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", user.ID, err))
+		return
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	data.Roles = []types.String{}
+	data.RoleNames = []types.String{}
+	for _, role := range roles {
+		data.Roles = append(data.Roles, types.StringValue(role.ID))
+		data.RoleNames = append(data.RoleNames, types.StringValue(role.Name))
+	}
+
+	tflog.Debug(ctx, "Storing local user into the state", map[string]interface{}{
+		"username": username,
+		"id":       user.ID,
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -3,10 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 	"terraform-provider-privx/internal/utils"
+	"time"
 
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
 	"github.com/SSHcom/privx-sdk-go/v2/api/userstore"
 	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,19 +19,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &LocalUserResource{}
 var _ resource.ResourceWithImportState = &LocalUserResource{}
+var _ resource.ResourceWithModifyPlan = &LocalUserResource{}
 
 func NewLocalUserResource() resource.Resource {
 	return &LocalUserResource{}
 }
 
 type LocalUserResource struct {
-	client *userstore.UserStore
+	client     *userstore.UserStore
+	roleClient *rolestore.RoleStore
 }
 
 type LocalUserResourceModel struct {
@@ -46,6 +54,12 @@ type LocalUserResourceModel struct {
 	UnixAccount            types.String `tfsdk:"unix_account"`
 	WindowsAccount         types.String `tfsdk:"windows_account"`
 	Tags                   types.List   `tfsdk:"tags"`
+	Roles                  types.Set    `tfsdk:"roles"`
+	RoleNames              types.Set    `tfsdk:"role_names"`
+	Disabled               types.Bool   `tfsdk:"disabled"`
+	PasswordExpires        types.String `tfsdk:"password_expires"`
+	PasswordCreated        types.String `tfsdk:"password_created"`
+	MFAResetTrigger        types.String `tfsdk:"mfa_reset_trigger"`
 }
 
 func (r *LocalUserResource) Metadata(
@@ -150,6 +164,47 @@ func (r *LocalUserResource) Schema(
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"roles": schema.SetAttribute{
+				MarkdownDescription: "IDs of the roles explicitly granted to the user. When set, other explicit grants are revoked. " +
+					"Roles granted through source rules are not affected. Conflicts with `role_names`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ConflictsWith(path.MatchRoot("role_names")),
+				},
+			},
+			"role_names": schema.SetAttribute{
+				MarkdownDescription: "Names of the roles explicitly granted to the user. When set, other explicit grants are revoked. Conflicts with `roles`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Disable the user for offboarding. PrivX local users have no native disabled or locked state, so while disabled all " +
+					"explicit role grants of the user are revoked; `roles` and `role_names` are kept in configuration and granted again when re-enabled. " +
+					"The user can still log in, and keeps roles granted through source rules; remove the user to block logins.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"password_expires": schema.StringAttribute{
+				MarkdownDescription: "Password expiry time (RFC3339). Once it has passed and the password has not been changed since, " +
+					"the next plan sets `password_change_required` to true, unless `password_change_required` is set in the configuration.",
+				Optional: true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"password_created": schema.StringAttribute{
+				MarkdownDescription: "Time the current password was set, as reported by PrivX",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mfa_reset_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value; changing it resets the multi-factor authentication of the user",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -173,6 +228,63 @@ func (r *LocalUserResource) Configure(
 	}
 
 	r.client = userstore.New(*connector)
+	r.roleClient = rolestore.New(*connector)
+}
+
+// ModifyPlan forces a password change once password_expires has passed,
+// unless password_change_required is set in the configuration.
+func (r *LocalUserResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state LocalUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PasswordExpires.IsNull() || plan.PasswordExpires.IsUnknown() || plan.PasswordChangeRequired.ValueBool() {
+		return
+	}
+
+	expires, err := time.Parse(time.RFC3339, plan.PasswordExpires.ValueString())
+	if err != nil || time.Now().Before(expires) {
+		return
+	}
+
+	// A password set after the expiry time is not expired.
+	if created, err := time.Parse(time.RFC3339, state.PasswordCreated.ValueString()); err == nil && created.After(expires) {
+		return
+	}
+
+	// Only an unconfigured value may be changed by the provider; an explicit
+	// password_change_required = false is kept and only warned about.
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_change_required"), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !configured.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("password_change_required"),
+			"Password Expired",
+			fmt.Sprintf("The password of %s expired at %s, but password_change_required is set to false in the configuration, so no password change is forced.", plan.Username.ValueString(), expires.Format(time.RFC3339)),
+		)
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("password_change_required"),
+		"Password Expired",
+		fmt.Sprintf("The password of %s expired at %s; the user must change it at next login.", plan.Username.ValueString(), expires.Format(time.RFC3339)),
+	)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password_change_required"), true)...)
 }
 
 func (r *LocalUserResource) Create(
@@ -229,6 +341,27 @@ func (r *LocalUserResource) Create(
 	}
 
 	data.ID = types.StringValue(identifier.ID)
+	data.PasswordCreated = types.StringNull()
+
+	// Save the user before granting roles so that a failed grant taints it
+	// instead of leaving the user behind in PrivX.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.applyRoles(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.GetUser(identifier.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+	data.PasswordCreated = types.StringValue(created.Password.Created)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -266,6 +399,12 @@ func (r *LocalUserResource) Read(
 	data.UnixAccount = types.StringValue(user.UnixAccount)
 	data.WindowsAccount = types.StringValue(user.WindowsAccount)
 	data.PasswordChangeRequired = types.BoolValue(user.PasswordChangeRequired)
+	data.PasswordCreated = types.StringValue(user.Password.Created)
+
+	resp.Diagnostics.Append(r.readRoles(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -316,6 +455,36 @@ func (r *LocalUserResource) Update(
 		return
 	}
 
+	resp.Diagnostics.Append(r.applyRoles(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state LocalUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.MFAResetTrigger.Equal(state.MFAResetTrigger) && !data.MFAResetTrigger.IsNull() {
+		tflog.Info(ctx, "Resetting MFA of local user", map[string]any{
+			"id": data.ID.ValueString(),
+		})
+		if err := r.roleClient.SetMFA([]string{data.ID.ValueString()}, rolestore.MFAActionReset); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to reset MFA of local user: %s", err),
+			)
+			return
+		}
+	}
+
+	updated, err := r.client.GetUser(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		return
+	}
+	data.PasswordCreated = types.StringValue(updated.Password.Created)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -351,3 +520,134 @@ func (r *LocalUserResource) ImportState(
 ) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// desiredRoleIDs returns the explicit role IDs the user should have, and
+// whether roles are managed at all.
+func (r *LocalUserResource) desiredRoleIDs(ctx context.Context, data *LocalUserResourceModel) ([]string, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !data.Roles.IsNull() && !data.Roles.IsUnknown():
+		var ids []string
+		diags.Append(data.Roles.ElementsAs(ctx, &ids, false)...)
+		return ids, true, diags
+
+	case !data.RoleNames.IsNull() && !data.RoleNames.IsUnknown():
+		var names []string
+		diags.Append(data.RoleNames.ElementsAs(ctx, &names, false)...)
+		if diags.HasError() || len(names) == 0 {
+			return []string{}, true, diags
+		}
+
+		resolved, err := r.roleClient.ResolveRoles(names)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to resolve role names, got error: %s", err))
+			return nil, true, diags
+		}
+
+		byName := make(map[string]string, len(resolved.Items))
+		for _, role := range resolved.Items {
+			byName[role.Name] = role.ID
+		}
+		ids := make([]string, 0, len(names))
+		for _, name := range names {
+			id, ok := byName[name]
+			if !ok {
+				diags.AddAttributeError(path.Root("role_names"), "Role Not Found", fmt.Sprintf("Role %q does not exist", name))
+				continue
+			}
+			ids = append(ids, id)
+		}
+		return ids, true, diags
+	}
+
+	return nil, false, diags
+}
+
+// applyRoles makes the explicit role grants of the user match the plan. A
+// disabled user has no explicit grants.
+func (r *LocalUserResource) applyRoles(ctx context.Context, data *LocalUserResourceModel) diag.Diagnostics {
+	ids, managed, diags := r.desiredRoleIDs(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+	if data.Disabled.ValueBool() {
+		ids, managed = []string{}, true
+	}
+	if !managed {
+		return diags
+	}
+
+	userID := data.ID.ValueString()
+	unlock := lockUserRoles(userID)
+	defer unlock()
+
+	current, err := getExplicitUserRoles(r.roleClient, userID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", userID, err))
+		return diags
+	}
+
+	roles := make([]rolestore.Role, 0, len(ids))
+	for _, id := range ids {
+		if existing := findRole(current, id); existing != nil {
+			roles = append(roles, *existing)
+			continue
+		}
+		roles = append(roles, rolestore.Role{ID: id, Explicit: true, GrantType: roleGrantPermanent})
+	}
+
+	tflog.Debug(ctx, "Updating explicit roles of local user", map[string]any{
+		"id":    userID,
+		"roles": ids,
+	})
+	if err := r.roleClient.UpdateUserRoles(userID, roles); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to update roles of user %s, got error: %s", userID, err))
+	}
+	return diags
+}
+
+// readRoles refreshes the managed role attributes from the explicit grants
+// of the user. Explicit grants on a disabled user are reported as drift of
+// the disabled flag.
+func (r *LocalUserResource) readRoles(ctx context.Context, data *LocalUserResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	managed := !data.Roles.IsNull() || !data.RoleNames.IsNull()
+	if !managed && !data.Disabled.ValueBool() {
+		return diags
+	}
+
+	current, err := getExplicitUserRoles(r.roleClient, data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", data.ID.ValueString(), err))
+		return diags
+	}
+
+	if data.Disabled.ValueBool() {
+		if len(current) > 0 {
+			data.Disabled = types.BoolValue(false)
+		}
+		return diags
+	}
+
+	ids := make([]string, 0, len(current))
+	names := make([]string, 0, len(current))
+	for _, role := range current {
+		ids = append(ids, role.ID)
+		names = append(names, role.Name)
+	}
+	sort.Strings(ids)
+	sort.Strings(names)
+
+	var d diag.Diagnostics
+	if !data.Roles.IsNull() {
+		data.Roles, d = types.SetValueFrom(ctx, types.StringType, ids)
+		diags.Append(d...)
+	}
+	if !data.RoleNames.IsNull() {
+		data.RoleNames, d = types.SetValueFrom(ctx, types.StringType, names)
+		diags.Append(d...)
+	}
+	return diags
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLocalUserRoles(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_local_user.test"
+	dataSourceName := "data.privx_local_user.test"
+
+	cfg1 := testAccLocalUserRolesConfig(suffix, false)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccLocalUserRolesConfig(suffix, true)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE with a role granted by name
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "disabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "role_names.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "password_created"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "roles.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "roles.0", "privx_role.test", "id"),
+				),
+			},
+
+			// DISABLE: explicit grants are revoked
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "disabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "role_names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "roles.#", "0"),
+				),
+			},
+
+			// RE-ENABLE: configured roles are granted again
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "disabled", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "roles.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccLocalUserRolesConfig(suffix string, disabled bool) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name    = "tf-acc-lu-%[1]s"
+  comment = "temp access group for local user roles test"
+}
+
+resource "privx_role" "test" {
+  name            = "tf-acc-lu-%[1]s"
+  access_group_id = privx_access_group.test.id
+
+  permissions  = ["users-view"]
+  permit_agent = false
+
+  source_rules = jsonencode({
+    type  = "GROUP"
+    match = "ANY"
+    rules = []
+  })
+}
+
+resource "privx_local_user" "test" {
+  username   = "tf-acc-lu-%[1]s"
+  full_name  = "Terraform provider Test User"
+  email      = "tf-acc-lu-%[1]s@example.com"
+  role_names = [privx_role.test.name]
+  disabled   = %[2]t
+}
+
+data "privx_local_user" "test" {
+  username = privx_local_user.test.username
+
+  depends_on = [privx_local_user.test]
+}
+`, suffix, disabled)
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/secretsmanager"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ManagedAccountDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ManagedAccountDataSource{}
+
+func NewManagedAccountDataSource() datasource.DataSource {
+	return &ManagedAccountDataSource{}
+}
+
+// ManagedAccountDataSource defines the data source implementation.
+type ManagedAccountDataSource struct {
+	client *secretsmanager.SecretsManager
+}
+
+// ManagedAccountDataSourceModel describes the data source data model.
+type ManagedAccountDataSourceModel struct {
+	ID                 types.String                       `tfsdk:"id"`
+	TargetDomainID     types.String                       `tfsdk:"target_domain_id"`
+	Username           types.String                       `tfsdk:"username"`
+	Comment            types.String                       `tfsdk:"comment"`
+	Enabled            types.Bool                         `tfsdk:"enabled"`
+	RotationEnabled    types.Bool                         `tfsdk:"rotation_enabled"`
+	ExplicitCheckout   types.Bool                         `tfsdk:"explicit_checkout"`
+	PasswordPolicyID   types.String                       `tfsdk:"password_policy_id"`
+	State              types.String                       `tfsdk:"state"`
+	Locked             types.Bool                         `tfsdk:"locked"`
+	SecretName         types.String                       `tfsdk:"secret_name"`
+	LastRotated        types.String                       `tfsdk:"last_rotated"`
+	LastRotationStatus types.String                       `tfsdk:"last_rotation_status"`
+	RotationFailures   types.Int64                        `tfsdk:"rotation_failures"`
+	RotationHistory    []ManagedAccountRotationEventModel `tfsdk:"rotation_history"`
+}
+
+func (d *ManagedAccountDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_account"
+}
+
+func (d *ManagedAccountDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Managed account data source. Looks up a secrets manager managed account by ID or username and exposes its rotation status.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Managed account ID. Set exactly one of `id` or `username`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"target_domain_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the target domain the account belongs to",
+				Required:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the managed account. Set exactly one of `id` or `username`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "A comment describing the managed account",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the managed account is enabled",
+				Computed:            true,
+			},
+			"rotation_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether periodic password rotation is enabled",
+				Computed:            true,
+			},
+			"explicit_checkout": schema.BoolAttribute{
+				MarkdownDescription: "Whether the password must be explicitly checked out before use",
+				Computed:            true,
+			},
+			"password_policy_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the password policy used for rotation",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Managed account state reported by PrivX",
+				Computed:            true,
+			},
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the managed account is locked",
+				Computed:            true,
+			},
+			"secret_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the vault secret holding the managed password",
+				Computed:            true,
+			},
+			"last_rotated": schema.StringAttribute{
+				MarkdownDescription: "Time of the latest rotation attempt (RFC3339), empty if never rotated",
+				Computed:            true,
+			},
+			"last_rotation_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the latest rotation attempt, empty if never rotated",
+				Computed:            true,
+			},
+			"rotation_failures": schema.Int64Attribute{
+				MarkdownDescription: "Number of failed rotation attempts in the rotation history",
+				Computed:            true,
+			},
+			"rotation_history": schema.ListNestedAttribute{
+				MarkdownDescription: "Password rotation history as reported by PrivX",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							MarkdownDescription: "Secret version produced by the rotation",
+							Computed:            true,
+						},
+						"rotated": schema.StringAttribute{
+							MarkdownDescription: "Rotation time (RFC3339)",
+							Computed:            true,
+						},
+						"trigger": schema.StringAttribute{
+							MarkdownDescription: "What triggered the rotation",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Rotation status",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ManagedAccountDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating secretsmanager", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = secretsmanager.New(*connector)
+}
+
+func (d *ManagedAccountDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("username"),
+		),
+	}
+}
+
+func (d *ManagedAccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ManagedAccountDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tdID := data.TargetDomainID.ValueString()
+
+	var account *secretsmanager.ManagedAccount
+	if !data.ID.IsNull() && data.ID.ValueString() != "" {
+		found, err := d.client.GetManagedAccount(tdID, data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed account, got error: %s", err))
+			return
+		}
+		account = found
+	} else {
+		found, err := d.findByUsername(tdID, data.Username.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search managed accounts, got error: %s", err))
+			return
+		}
+		account = found
+		if account == nil {
+			resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Managed account with username '%s' not found in target domain %s", data.Username.ValueString(), tdID))
+			return
+		}
+	}
+
+	status := flattenManagedAccountRotation(account)
+
+	data.ID = types.StringValue(account.ID)
+	data.Username = types.StringValue(account.Username)
+	data.Comment = types.StringValue(account.Comment)
+	data.Enabled = types.BoolValue(account.Enabled)
+	data.RotationEnabled = types.BoolValue(account.RotationEnabled)
+	data.ExplicitCheckout = types.BoolValue(account.ExplicitCheckout)
+	data.PasswordPolicyID = types.StringValue("")
+	if account.PasswordPolicy != nil {
+		data.PasswordPolicyID = types.StringValue(account.PasswordPolicy.ID)
+	}
+	data.State = types.StringValue(account.State)
+	data.Locked = types.BoolValue(account.Locked)
+	data.SecretName = types.StringValue(account.SecretName)
+	data.LastRotated = status.LastRotated
+	data.LastRotationStatus = status.LastRotationStatus
+	data.RotationFailures = status.RotationFailures
+	data.RotationHistory = status.History
+
+	tflog.Debug(ctx, "Storing managed account type into the state", map[string]interface{}{
+		"createNewState": fmt.Sprintf("%+v", data),
+	})
+	// Save updated data into Terraform state.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findByUsername returns the managed account with exactly the given username,
+// or nil if there is none. The keyword search also matches similar usernames,
+// so every page of results is checked.
+func (d *ManagedAccountDataSource) findByUsername(tdID, username string) (*secretsmanager.ManagedAccount, error) {
+	search := secretsmanager.ManagedAccountsSearch{Keywords: username}
+	for offset := 0; ; offset += listPageSize {
+		page, err := d.client.SearchManagedAccounts(tdID, search, filters.Paging(offset, listPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Items {
+			if page.Items[i].Username == username {
+				return &page.Items[i], nil
+			}
+		}
+		if len(page.Items) < listPageSize {
+			return nil, nil
+		}
+	}
+}
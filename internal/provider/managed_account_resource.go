@@ -0,0 +1,478 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-privx/internal/utils"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/secretsmanager"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ManagedAccountResource{}
+var _ resource.ResourceWithImportState = &ManagedAccountResource{}
+
+func NewManagedAccountResource() resource.Resource {
+	return &ManagedAccountResource{}
+}
+
+// ManagedAccountResource defines the resource implementation.
+type ManagedAccountResource struct {
+	client *secretsmanager.SecretsManager
+}
+
+// ManagedAccountResourceModel contains PrivX secrets manager managed account information.
+type ManagedAccountResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	TargetDomainID     types.String `tfsdk:"target_domain_id"`
+	Username           types.String `tfsdk:"username"`
+	Comment            types.String `tfsdk:"comment"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	RotationEnabled    types.Bool   `tfsdk:"rotation_enabled"`
+	ExplicitCheckout   types.Bool   `tfsdk:"explicit_checkout"`
+	DisableRDPCertAuth types.Bool   `tfsdk:"disable_rdp_cert_auth"`
+	PasswordPolicyID   types.String `tfsdk:"password_policy_id"`
+	RotateTrigger      types.String `tfsdk:"rotate_trigger"`
+	State              types.String `tfsdk:"state"`
+	Locked             types.Bool   `tfsdk:"locked"`
+	SecretName         types.String `tfsdk:"secret_name"`
+	LastRotated        types.String `tfsdk:"last_rotated"`
+	LastRotationStatus types.String `tfsdk:"last_rotation_status"`
+	RotationFailures   types.Int64  `tfsdk:"rotation_failures"`
+	RotationHistory    types.List   `tfsdk:"rotation_history"`
+}
+
+// ManagedAccountRotationEventModel describes a single rotation history entry.
+type ManagedAccountRotationEventModel struct {
+	Version types.Int64  `tfsdk:"version"`
+	Rotated types.String `tfsdk:"rotated"`
+	Trigger types.String `tfsdk:"trigger"`
+	Status  types.String `tfsdk:"status"`
+}
+
+var managedAccountRotationEventAttrTypes = map[string]attr.Type{
+	"version": types.Int64Type,
+	"rotated": types.StringType,
+	"trigger": types.StringType,
+	"status":  types.StringType,
+}
+
+func (r *ManagedAccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_account"
+}
+
+func (r *ManagedAccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Managed account resource. Manages a secrets manager managed account in a target domain and exposes its password rotation status.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Managed account ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_domain_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the target domain the account belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username of the managed account",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "A comment describing the managed account",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the managed account is enabled",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"rotation_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether periodic password rotation is enabled",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"explicit_checkout": schema.BoolAttribute{
+				MarkdownDescription: "Whether the password must be explicitly checked out before use",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"disable_rdp_cert_auth": schema.BoolAttribute{
+				MarkdownDescription: "Disable RDP certificate authentication for the managed account",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"password_policy_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the password policy used for rotation. Uses the target domain default when omitted.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotate_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value; changing it triggers an immediate password rotation on the next apply",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Managed account state reported by PrivX",
+				Computed:            true,
+			},
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the managed account is locked",
+				Computed:            true,
+			},
+			"secret_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the vault secret holding the managed password",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_rotated": schema.StringAttribute{
+				MarkdownDescription: "Time of the latest rotation attempt (RFC3339), empty if never rotated",
+				Computed:            true,
+			},
+			"last_rotation_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the latest rotation attempt, empty if never rotated",
+				Computed:            true,
+			},
+			"rotation_failures": schema.Int64Attribute{
+				MarkdownDescription: "Number of failed rotation attempts in the rotation history",
+				Computed:            true,
+			},
+			"rotation_history": schema.ListNestedAttribute{
+				MarkdownDescription: "Password rotation history as reported by PrivX",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							MarkdownDescription: "Secret version produced by the rotation",
+							Computed:            true,
+						},
+						"rotated": schema.StringAttribute{
+							MarkdownDescription: "Rotation time (RFC3339)",
+							Computed:            true,
+						},
+						"trigger": schema.StringAttribute{
+							MarkdownDescription: "What triggered the rotation",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "Rotation status",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ManagedAccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating secretsmanager", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = secretsmanager.New(*connector)
+}
+
+func (r *ManagedAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ManagedAccountResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Loaded managed account type data", map[string]interface{}{
+		"data": fmt.Sprintf("%+v", data),
+	})
+
+	tdID := data.TargetDomainID.ValueString()
+	account := secretsmanager.ManagedAccount{
+		Username:           data.Username.ValueString(),
+		TargetDomain:       secretsmanager.TargetDomainHandle{ID: tdID},
+		Comment:            data.Comment.ValueString(),
+		Enabled:            data.Enabled.ValueBool(),
+		RotationEnabled:    data.RotationEnabled.ValueBool(),
+		ExplicitCheckout:   data.ExplicitCheckout.ValueBool(),
+		DisableRDPCertAuth: data.DisableRDPCertAuth.ValueBool(),
+	}
+	if !data.PasswordPolicyID.IsNull() && !data.PasswordPolicyID.IsUnknown() && data.PasswordPolicyID.ValueString() != "" {
+		account.PasswordPolicy = &secretsmanager.PasswordPolicyHandle{ID: data.PasswordPolicyID.ValueString()}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("secretsmanager.ManagedAccount model used: %+v", account))
+
+	accountID, err := r.client.CreateManagedAccount(tdID, &account)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(accountID.ID)
+
+	created, err := r.client.GetManagedAccount(tdID, accountID.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed account after create, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.setRotationStatus(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "created managed account resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ManagedAccountResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, err := r.client.GetManagedAccount(data.TargetDomainID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed account, got error: %s", err))
+		return
+	}
+
+	data.Username = types.StringValue(account.Username)
+	data.Comment = types.StringValue(account.Comment)
+	data.Enabled = types.BoolValue(account.Enabled)
+	data.RotationEnabled = types.BoolValue(account.RotationEnabled)
+	data.ExplicitCheckout = types.BoolValue(account.ExplicitCheckout)
+	data.DisableRDPCertAuth = types.BoolValue(account.DisableRDPCertAuth)
+
+	resp.Diagnostics.Append(data.setRotationStatus(ctx, account)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Storing managed account type into the state", map[string]interface{}{
+		"createNewState": fmt.Sprintf("%+v", data),
+	})
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ManagedAccountResourceModel
+	var state ManagedAccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tdID := plan.TargetDomainID.ValueString()
+
+	// Fetch the current managed account to preserve read-only fields
+	current, err := r.client.GetManagedAccount(tdID, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read current managed account, got error: %s", err))
+		return
+	}
+
+	current.Comment = plan.Comment.ValueString()
+	current.Enabled = plan.Enabled.ValueBool()
+	current.RotationEnabled = plan.RotationEnabled.ValueBool()
+	current.ExplicitCheckout = plan.ExplicitCheckout.ValueBool()
+	current.DisableRDPCertAuth = plan.DisableRDPCertAuth.ValueBool()
+	if !plan.PasswordPolicyID.IsNull() && !plan.PasswordPolicyID.IsUnknown() && plan.PasswordPolicyID.ValueString() != "" {
+		current.PasswordPolicy = &secretsmanager.PasswordPolicyHandle{ID: plan.PasswordPolicyID.ValueString()}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("secretsmanager.ManagedAccount model used: %+v", current))
+
+	err = r.client.UpdateTargetManagedAccount(tdID, plan.ID.ValueString(), current)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update managed account, got error: %s", err))
+		return
+	}
+
+	if !plan.RotateTrigger.IsNull() && !plan.RotateTrigger.Equal(state.RotateTrigger) {
+		tflog.Info(ctx, fmt.Sprintf("rotate_trigger changed, rotating managed account %s password", plan.ID.ValueString()))
+		err = r.client.RotateManagedAccountPassword(tdID, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate managed account password, got error: %s", err))
+			return
+		}
+	}
+
+	updated, err := r.client.GetManagedAccount(tdID, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed account after update, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(plan.setRotationStatus(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ManagedAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ManagedAccountResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteManagedAccount(data.TargetDomainID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete managed account, got error: %s", err))
+		return
+	}
+}
+
+func (r *ManagedAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Managed accounts are scoped to a target domain: "<target_domain_id>/<managed_account_id>"
+	parts := strings.Split(strings.TrimSpace(req.ID), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid import id", "Expected '<target_domain_id>/<managed_account_id>'.")
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("target_domain_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// setRotationStatus copies the server-managed fields into the model.
+func (data *ManagedAccountResourceModel) setRotationStatus(ctx context.Context, account *secretsmanager.ManagedAccount) diag.Diagnostics {
+	status := flattenManagedAccountRotation(account)
+
+	if account.PasswordPolicy != nil && account.PasswordPolicy.ID != "" {
+		data.PasswordPolicyID = types.StringValue(account.PasswordPolicy.ID)
+	} else {
+		data.PasswordPolicyID = types.StringNull()
+	}
+	data.State = types.StringValue(account.State)
+	data.Locked = types.BoolValue(account.Locked)
+	data.SecretName = types.StringValue(account.SecretName)
+	data.LastRotated = status.LastRotated
+	data.LastRotationStatus = status.LastRotationStatus
+	data.RotationFailures = status.RotationFailures
+
+	history, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: managedAccountRotationEventAttrTypes}, status.History)
+	data.RotationHistory = history
+	return diags
+}
+
+// managedAccountRotation is the flattened rotation status shared by the
+// managed account resource and data source.
+type managedAccountRotation struct {
+	LastRotated        types.String
+	LastRotationStatus types.String
+	RotationFailures   types.Int64
+	History            []ManagedAccountRotationEventModel
+}
+
+func flattenManagedAccountRotation(account *secretsmanager.ManagedAccount) managedAccountRotation {
+	status := managedAccountRotation{
+		LastRotated:        types.StringValue(""),
+		LastRotationStatus: types.StringValue(""),
+		History:            []ManagedAccountRotationEventModel{},
+	}
+
+	var failures int64
+	var latest *secretsmanager.SecretRotationEvent
+	for i, event := range account.RotationHistory {
+		if isRotationFailure(event.Status) {
+			failures++
+		}
+		if latest == nil || event.Rotated.After(latest.Rotated) {
+			latest = &account.RotationHistory[i]
+		}
+		status.History = append(status.History, ManagedAccountRotationEventModel{
+			Version: types.Int64Value(int64(event.Version)),
+			Rotated: types.StringValue(event.Rotated.Format(time.RFC3339)),
+			Trigger: types.StringValue(event.Trigger),
+			Status:  types.StringValue(event.Status),
+		})
+	}
+
+	if latest != nil {
+		status.LastRotated = types.StringValue(latest.Rotated.Format(time.RFC3339))
+		status.LastRotationStatus = types.StringValue(latest.Status)
+	}
+	status.RotationFailures = types.Int64Value(failures)
+
+	return status
+}
+
+// isRotationFailure reports whether a rotation event status denotes a failed rotation.
+func isRotationFailure(status string) bool {
+	s := strings.ToUpper(status)
+	return strings.Contains(s, "FAIL") || strings.Contains(s, "ERROR")
+}
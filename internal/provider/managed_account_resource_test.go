@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testAccManagedAccountPreCheck skips unless a target domain with a scanned
+// account is available, as target domains need a reachable directory.
+func testAccManagedAccountPreCheck(t *testing.T) {
+	if os.Getenv("PRIVX_ACC_TARGET_DOMAIN_ID") == "" || os.Getenv("PRIVX_ACC_MANAGED_ACCOUNT_USERNAME") == "" {
+		t.Skip(
+			"Skipping managed account acceptance test. " +
+				"Set PRIVX_ACC_TARGET_DOMAIN_ID and PRIVX_ACC_MANAGED_ACCOUNT_USERNAME to enable.",
+		)
+	}
+}
+
+func TestAccManagedAccountResource(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	testAccManagedAccountPreCheck(t)
+
+	tdID := os.Getenv("PRIVX_ACC_TARGET_DOMAIN_ID")
+	username := os.Getenv("PRIVX_ACC_MANAGED_ACCOUNT_USERNAME")
+	resourceName := "privx_managed_account.test"
+
+	cfg1 := testAccManagedAccountConfig(tdID, username, "created by terraform", "1")
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccManagedAccountConfig(tdID, username, "updated by terraform", "2")
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "username", username),
+					resource.TestCheckResourceAttr(resourceName, "comment", "created by terraform"),
+					resource.TestCheckResourceAttrSet(resourceName, "state"),
+					resource.TestCheckResourceAttrSet(resourceName, "rotation_failures"),
+					resource.TestCheckResourceAttrPair("data.privx_managed_account.test", "id", resourceName, "id"),
+				),
+			},
+
+			// UPDATE + on-demand rotation
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "comment", "updated by terraform"),
+					resource.TestCheckResourceAttr(resourceName, "rotate_trigger", "2"),
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", resourceName)
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["target_domain_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerifyIgnore: []string{
+					"rotate_trigger",
+					"rotation_history",
+					"last_rotated",
+					"last_rotation_status",
+					"rotation_failures",
+					"state",
+				},
+			},
+		},
+	})
+}
+
+func testAccManagedAccountConfig(tdID, username, comment, trigger string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_managed_account" "test" {
+  target_domain_id = %q
+  username         = %q
+  comment          = %q
+  rotate_trigger   = %q
+}
+
+data "privx_managed_account" "test" {
+  target_domain_id = privx_managed_account.test.target_domain_id
+  username         = privx_managed_account.test.username
+}
+`, tdID, username, comment, trigger)
+}
@@ -3,12 +3,16 @@ package provider
 import (
 	"context"
 	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -24,6 +28,13 @@ type privxProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// metrics counts the PrivX API requests of this provider process.
+	metrics *client.Metrics
+
+	// readOnly is set when the provider is configured in read-only mode.
+	// The protocol server then refuses every apply before the resource runs.
+	readOnly bool
 }
 
 // privxProviderModel describes the provider data model.
@@ -35,6 +46,9 @@ type privxProviderModel struct {
 	OAuthClientID     types.String `tfsdk:"api_oauth_client_id"`
 	OAuthClientSecret types.String `tfsdk:"api_oauth_client_secret"`
 	Debug             types.Bool   `tfsdk:"debug"`
+	MaxConcurrent     types.Int64  `tfsdk:"max_concurrent_requests"`
+	ProtectedIDs      types.Set    `tfsdk:"protected_ids"`
+	ReadOnly          types.Bool   `tfsdk:"read_only"`
 }
 
 func (p *privxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -76,6 +90,30 @@ func (p *privxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "PrivX debug mode",
 				Optional:            true,
 			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of PrivX API requests in flight at a time, across all resources and data sources. " +
+					"Unlimited when unset. May also be set with the PRIVX_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"protected_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of PrivX objects that must never be deleted, regardless of resource configuration. " +
+					"Secrets are identified by name. Any API delete with one of these IDs in its path fails, which covers deleting the object " +
+					"itself, hosts removed from a `privx_host_set`, and sub-objects such as the principal keys of a protected role. " +
+					"Revoking a protected role from a user, or any role from a protected user, also fails; this covers " +
+					"`privx_role_member`, `privx_role_members` and `disabled` on `privx_local_user`. Other updates of protected objects are allowed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Refuse every change to PrivX: create, update and delete of any resource fail before the resource makes any API request. " +
+					"Refreshes, plans and data sources work as usual, " +
+					"except the data sources that open a download session (`privx_carrier_config`, `privx_extender_config`, " +
+					"`privx_webproxy_config` and `privx_host_deploy_script`), which fail. May also be set with the PRIVX_READ_ONLY environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -146,6 +184,33 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if data.MaxConcurrent.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown PrivX max concurrent requests",
+			"The provider cannot create the PrivX API client as there is an unknown configuration value for the maximum number of concurrent requests. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PRIVX_MAX_CONCURRENT_REQUESTS environment variable.",
+		)
+	}
+
+	if data.ProtectedIDs.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("protected_ids"),
+			"Unknown PrivX protected IDs",
+			"The provider cannot create the PrivX API client as there is an unknown configuration value for the protected object IDs. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if data.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown PrivX read-only mode",
+			"The provider cannot create the PrivX API client as there is an unknown configuration value for the read-only mode. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PRIVX_READ_ONLY environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -153,41 +218,75 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	apiBaseURL := os.Getenv("PRIVX_API_BASE_URL")
-	apiBearerToken := os.Getenv("PRIVX_API_BEARER_TOKEN")
-	apiClientID := os.Getenv("PRIVX_API_CLIENT_ID")
-	apiClientSecret := os.Getenv("PRIVX_API_CLIENT_SECRET")
-	oauthClientID := os.Getenv("PRIVX_API_OAUTH_CLIENT_ID")
-	oauthClientSecret := os.Getenv("PRIVX_API_OAUTH_CLIENT_SECRET")
+	config := client.ConfigFromEnv()
 
 	if !data.APIBaseURL.IsNull() {
-		apiBaseURL = data.APIBaseURL.ValueString()
+		config.APIBaseURL = data.APIBaseURL.ValueString()
 	}
 
 	if !data.APIBearerToken.IsNull() {
-		apiBearerToken = data.APIBearerToken.ValueString()
+		config.BearerToken = data.APIBearerToken.ValueString()
 	}
 
 	if !data.APIClientID.IsNull() {
-		apiClientID = data.APIClientID.ValueString()
+		config.APIClientID = data.APIClientID.ValueString()
 	}
 
 	if !data.APIClientSecret.IsNull() {
-		apiClientSecret = data.APIClientSecret.ValueString()
+		config.APIClientSecret = data.APIClientSecret.ValueString()
 	}
 
 	if !data.OAuthClientID.IsNull() {
-		oauthClientID = data.OAuthClientID.ValueString()
+		config.OAuthClientID = data.OAuthClientID.ValueString()
 	}
 
 	if !data.OAuthClientSecret.IsNull() {
-		oauthClientSecret = data.OAuthClientSecret.ValueString()
+		config.OAuthClientSecret = data.OAuthClientSecret.ValueString()
+	}
+
+	var maxConcurrent int64
+	if value := os.Getenv("PRIVX_MAX_CONCURRENT_REQUESTS"); value != "" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid PrivX max concurrent requests",
+				"The PRIVX_MAX_CONCURRENT_REQUESTS environment variable must be a positive integer, got: "+value,
+			)
+		}
+		maxConcurrent = n
+	}
+
+	if !data.MaxConcurrent.IsNull() {
+		maxConcurrent = data.MaxConcurrent.ValueInt64()
+	}
+
+	var readOnly bool
+	if value := os.Getenv("PRIVX_READ_ONLY"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("read_only"),
+				"Invalid PrivX read-only mode",
+				"The PRIVX_READ_ONLY environment variable must be a boolean, got: "+value,
+			)
+		}
+		readOnly = b
+	}
+
+	if !data.ReadOnly.IsNull() {
+		readOnly = data.ReadOnly.ValueBool()
+	}
+
+	var protectedIDs []string
+	if !data.ProtectedIDs.IsNull() {
+		resp.Diagnostics.Append(data.ProtectedIDs.ElementsAs(ctx, &protectedIDs, false)...)
 	}
 
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if apiBaseURL == "" {
+	if config.APIBaseURL == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_base_url"),
 			"Missing PrivX API base URL",
@@ -197,9 +296,9 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
-	if apiBearerToken == "" {
+	if config.BearerToken == "" {
 
-		if apiClientID == "" {
+		if config.APIClientID == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("api_client_id"),
 				"Missing PrivX API client ID",
@@ -209,7 +308,7 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			)
 		}
 
-		if apiClientSecret == "" {
+		if config.APIClientSecret == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("api_client_secret"),
 				"Missing PrivX API client secret",
@@ -219,7 +318,7 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			)
 		}
 
-		if oauthClientID == "" {
+		if config.OAuthClientID == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("api_oauth_client_id"),
 				"Missing PrivX OAuth client ID",
@@ -229,7 +328,7 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			)
 		}
 
-		if oauthClientSecret == "" {
+		if config.OAuthClientSecret == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("api_oauth_client_secret"),
 				"Missing PrivX API client secret",
@@ -245,19 +344,19 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	ctx = tflog.SetField(ctx, "api_base_url", apiBaseURL)
-	ctx = tflog.SetField(ctx, "api_bearer_token", apiBearerToken)
-	ctx = tflog.SetField(ctx, "api_client_id", apiClientID)
-	ctx = tflog.SetField(ctx, "api_client_secret", apiClientSecret)
-	ctx = tflog.SetField(ctx, "api_oauth_client_id", oauthClientID)
-	ctx = tflog.SetField(ctx, "api_oauth_client_secret", oauthClientSecret)
+	ctx = tflog.SetField(ctx, "api_base_url", config.APIBaseURL)
+	ctx = tflog.SetField(ctx, "api_bearer_token", config.BearerToken)
+	ctx = tflog.SetField(ctx, "api_client_id", config.APIClientID)
+	ctx = tflog.SetField(ctx, "api_client_secret", config.APIClientSecret)
+	ctx = tflog.SetField(ctx, "api_oauth_client_id", config.OAuthClientID)
+	ctx = tflog.SetField(ctx, "api_oauth_client_secret", config.OAuthClientSecret)
 	//	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_client_secret")
 	//	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_oauth_client_secret")
 	//	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_bearer_token")
 
 	tflog.Debug(ctx, "Creating PrivX client")
 
-	connector, err := client.NewConnector(apiBaseURL, apiBearerToken, apiClientID, apiClientSecret, oauthClientID, oauthClientSecret)
+	connector, err := client.NewConnector(config.APIBaseURL, config.BearerToken, config.APIClientID, config.APIClientSecret, config.OAuthClientID, config.OAuthClientSecret)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create PrivX client",
@@ -266,10 +365,27 @@ func (p *privxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 		return
 	}
-	resp.DataSourceData = connector
-	resp.ResourceData = connector
-
-	tflog.Info(ctx, "Configured PrivX API client", map[string]any{"success": true})
+	limited := client.NewLimiter(maxConcurrent, p.metrics).Wrap(*connector)
+	guard := client.NewGuard(protectedIDs, readOnly)
+	p.readOnly = readOnly
+
+	// Data sources share cached reads for the whole run; resources always
+	// read fresh and invalidate the cache when they write. Both go through
+	// the guard first so that refused requests are never sent; some data
+	// sources open download sessions, which are writes.
+	cache := client.NewCache()
+	reader := guard.Wrap(*cache.Reader(limited))
+	resp.DataSourceData = &reader
+
+	writer := guard.Wrap(*cache.Writer(limited))
+	resp.ResourceData = &writer
+
+	tflog.Info(ctx, "Configured PrivX API client", map[string]any{
+		"success":                 true,
+		"max_concurrent_requests": maxConcurrent,
+		"protected_ids":           len(protectedIDs),
+		"read_only":               readOnly,
+	})
 }
 
 func (p *privxProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -289,6 +405,18 @@ func (p *privxProvider) Resources(ctx context.Context) []func() resource.Resourc
 		NewLocalUserResource,
 		NewLocalUserPasswordResource,
 		NewNetworkTargetResource,
+		NewManagedAccountResource,
+		NewWebProxyResource,
+		NewLicenseResource,
+		NewSettingsResource,
+		NewRoleMemberResource,
+		NewRoleMembersResource,
+		NewRolePrincipalKeyResource,
+		NewUserAuthorizedKeyResource,
+		NewIdentityProviderResource,
+		NewWorkflowRequestResource,
+		NewConnectionTerminationResource,
+		NewHostSetResource,
 	}
 }
 
@@ -311,6 +439,23 @@ func (p *privxProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		NewWorkflowDataSource,
 		NewWhitelistDataSource,
 		NewCarrierDataSource,
+		NewManagedAccountDataSource,
+		NewLicenseDataSource,
+		NewSettingsDataSource,
+		NewUserAuthorizedKeysDataSource,
+		NewLocalUserDataSource,
+		NewConnectionsDataSource,
+		NewAuditEventsDataSource,
+		NewAuthorizerCAsDataSource,
+		NewRolePrincipalKeysDataSource,
+		NewHostDeployScriptDataSource,
+		NewDBProxyConfigDataSource,
+		NewHostsDataSource,
+		NewRolesDataSource,
+		NewAccessGroupsDataSource,
+		NewSecretsDataSource,
+		NewWorkflowsDataSource,
+		NewUsersDataSource,
 	}
 }
 
@@ -318,6 +463,7 @@ func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &privxProvider{
 			version: version,
+			metrics: client.NewMetrics(),
 		}
 	}
 }
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProviderReadOnly(t *testing.T) {
+	name := "tf-acc-readonly-" + acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+
+	cfgData := testAccProviderReadOnlyConfig(true, "")
+	cfgCreate := testAccProviderReadOnlyConfig(true, fmt.Sprintf(`
+resource "privx_access_group" "test" {
+  name    = %q
+  comment = "must never be created"
+}
+`, name))
+	cfgCheck := testAccProviderReadOnlyConfig(false, fmt.Sprintf(`
+data "privx_access_group" "check" {
+  name = %q
+}
+`, name))
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfgData)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfgCreate)
+	writeAccConfig(t, fmt.Sprintf("%s_step_3.tf", t.Name()), cfgCheck)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Data sources work
+			{
+				Config: cfgData,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.privx_access_group.ag", "id"),
+				),
+			},
+			// Creating the access group is refused before any request is sent
+			{
+				Config:      cfgCreate,
+				ExpectError: regexp.MustCompile(`read-only mode\s+\(read_only\s+or\s+PRIVX_READ_ONLY\),\s+refusing\s+to\s+change\s+privx_access_group`),
+			},
+			// The access group does not exist
+			{
+				Config: cfgCheck,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_access_group.check", "id", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccProviderReadOnlyConfig(readOnly bool, extra string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {
+  read_only = %t
+}
+
+data "privx_access_group" "ag" {
+  name = "Default"
+}
+%s`, readOnly, extra)
+}
@@ -0,0 +1,538 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleMemberResource{}
+var _ resource.ResourceWithImportState = &RoleMemberResource{}
+var _ resource.ResourceWithValidateConfig = &RoleMemberResource{}
+
+// Role grant types supported by PrivX.
+const (
+	roleGrantPermanent      = "PERMANENT"
+	roleGrantTimeRestricted = "TIME_RESTRICTED"
+	roleGrantFloating       = "FLOATING"
+)
+
+func NewRoleMemberResource() resource.Resource {
+	return &RoleMemberResource{}
+}
+
+// RoleMemberResource defines the resource implementation.
+type RoleMemberResource struct {
+	client *rolestore.RoleStore
+}
+
+// RoleMemberResourceModel contains an explicit role grant for a single user.
+type RoleMemberResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	UserID         types.String `tfsdk:"user_id"`
+	RoleID         types.String `tfsdk:"role_id"`
+	RoleName       types.String `tfsdk:"role_name"`
+	GrantType      types.String `tfsdk:"grant_type"`
+	GrantStart     types.String `tfsdk:"grant_start"`
+	GrantEnd       types.String `tfsdk:"grant_end"`
+	FloatingLength types.Int64  `tfsdk:"floating_length"`
+}
+
+func (r *RoleMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_member"
+}
+
+func (r *RoleMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role member resource. Explicitly grants one role to one user. Other explicit grants of the user are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Role member ID in the form `<user_id>/<role_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user the role is granted to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the granted role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the granted role",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"grant_type": schema.StringAttribute{
+				MarkdownDescription: "Grant type, one of `PERMANENT`, `TIME_RESTRICTED` or `FLOATING`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(roleGrantPermanent),
+				Validators: []validator.String{
+					stringvalidator.OneOf(roleGrantPermanent, roleGrantTimeRestricted, roleGrantFloating),
+				},
+			},
+			"grant_start": schema.StringAttribute{
+				MarkdownDescription: "Start of the grant (RFC3339), only for `TIME_RESTRICTED` grants",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"grant_end": schema.StringAttribute{
+				MarkdownDescription: "End of the grant (RFC3339), only for `TIME_RESTRICTED` grants",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"floating_length": schema.Int64Attribute{
+				MarkdownDescription: "Length of the floating grant in hours, required for `FLOATING` grants",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleMemberResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateRoleGrant(data.GrantType, data.GrantStart, data.GrantEnd, data.FloatingLength, path.Root)...)
+}
+
+func (r *RoleMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = rolestore.New(*connector)
+}
+
+func (r *RoleMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	roleID := data.RoleID.ValueString()
+
+	unlock := lockUserRoles(userID)
+	defer unlock()
+
+	roles, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", userID, err))
+		return
+	}
+	if findRole(roles, roleID) != nil {
+		resp.Diagnostics.AddError(
+			"Role Already Granted",
+			fmt.Sprintf("Role %s is already explicitly granted to user %s. Import it with id \"%s/%s\" to manage it.", roleID, userID, userID, roleID),
+		)
+		return
+	}
+
+	roles = append(roles, data.expand())
+	if err := r.client.UpdateUserRoles(userID, roles); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(userID + "/" + roleID)
+
+	granted, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", userID, err))
+		return
+	}
+	role := findRole(granted, roleID)
+	if role == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Role %s was not granted to user %s", roleID, userID))
+		return
+	}
+	data.flatten(role)
+
+	tflog.Debug(ctx, "created role member resource", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := getExplicitUserRoles(r.client, data.UserID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", data.UserID.ValueString(), err))
+		return
+	}
+
+	role := findRole(roles, data.RoleID.ValueString())
+	if role == nil {
+		tflog.Info(ctx, "Role is no longer explicitly granted, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.flatten(role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	roleID := data.RoleID.ValueString()
+
+	unlock := lockUserRoles(userID)
+	defer unlock()
+
+	roles, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", userID, err))
+		return
+	}
+
+	grant := data.expand()
+	if existing := findRole(roles, roleID); existing != nil {
+		*existing = grant
+	} else {
+		roles = append(roles, grant)
+	}
+
+	if err := r.client.UpdateUserRoles(userID, roles); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update roles of user %s, got error: %s", userID, err))
+		return
+	}
+
+	granted, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", userID, err))
+		return
+	}
+	role := findRole(granted, roleID)
+	if role == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Role %s was not granted to user %s", roleID, userID))
+		return
+	}
+	data.flatten(role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+
+	unlock := lockUserRoles(userID)
+	defer unlock()
+
+	roles, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles of user %s, got error: %s", userID, err))
+		return
+	}
+
+	remaining, removed := removeRole(roles, data.RoleID.ValueString())
+	if !removed {
+		return
+	}
+
+	if err := r.client.UpdateUserRoles(userID, remaining); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke role %s from user %s, got error: %s", data.RoleID.ValueString(), userID, err))
+		return
+	}
+}
+
+func (r *RoleMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userID, roleID, ok := strings.Cut(req.ID, "/")
+	if !ok || userID == "" || roleID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected import id in the form <user_id>/<role_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+}
+
+func (data *RoleMemberResourceModel) expand() rolestore.Role {
+	return expandRoleGrant(data.RoleID, data.GrantType, data.GrantStart, data.GrantEnd, data.FloatingLength)
+}
+
+func (data *RoleMemberResourceModel) flatten(role *rolestore.Role) {
+	data.RoleName = types.StringValue(role.Name)
+	data.GrantType, data.GrantStart, data.GrantEnd, data.FloatingLength =
+		flattenRoleGrant(role, data.GrantStart, data.GrantEnd)
+}
+
+// userRolesLocks serializes read-modify-write cycles on the explicit roles of
+// a user, as several role member resources may target the same user.
+var userRolesLocks sync.Map
+
+func lockUserRoles(userID string) func() {
+	mu, _ := userRolesLocks.LoadOrStore(userID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// getExplicitUserRoles returns the roles explicitly granted to a user. Roles
+// granted through source rules are not included.
+func getExplicitUserRoles(client *rolestore.RoleStore, userID string) ([]rolestore.Role, error) {
+	result, err := client.GetUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]rolestore.Role, 0, len(result.Items))
+	for _, role := range result.Items {
+		if role.Explicit {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func findRole(roles []rolestore.Role, roleID string) *rolestore.Role {
+	for i := range roles {
+		if roles[i].ID == roleID {
+			return &roles[i]
+		}
+	}
+	return nil
+}
+
+func removeRole(roles []rolestore.Role, roleID string) ([]rolestore.Role, bool) {
+	remaining := make([]rolestore.Role, 0, len(roles))
+	removed := false
+	for _, role := range roles {
+		if role.ID == roleID {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, role)
+	}
+	return remaining, removed
+}
+
+func expandRoleGrant(roleID, grantType, grantStart, grantEnd types.String, floatingLength types.Int64) rolestore.Role {
+	role := rolestore.Role{
+		ID:        roleID.ValueString(),
+		Explicit:  true,
+		GrantType: grantType.ValueString(),
+	}
+	if role.GrantType == "" {
+		role.GrantType = roleGrantPermanent
+	}
+
+	switch role.GrantType {
+	case roleGrantTimeRestricted:
+		role.GrantStart = grantStart.ValueString()
+		role.GrantEnd = grantEnd.ValueString()
+	case roleGrantFloating:
+		role.FloatingLength = floatingLength.ValueInt64()
+	}
+	return role
+}
+
+// flattenRoleGrant returns the grant attributes of a role. Prior time values
+// are kept when they denote the same instant as the ones reported by PrivX.
+func flattenRoleGrant(role *rolestore.Role, priorStart, priorEnd types.String) (types.String, types.String, types.String, types.Int64) {
+	grantType := role.GrantType
+	if grantType == "" {
+		grantType = roleGrantPermanent
+	}
+
+	grantStart := types.StringNull()
+	grantEnd := types.StringNull()
+	floatingLength := types.Int64Null()
+
+	switch grantType {
+	case roleGrantTimeRestricted:
+		grantStart = flattenGrantTime(role.GrantStart, priorStart)
+		grantEnd = flattenGrantTime(role.GrantEnd, priorEnd)
+	case roleGrantFloating:
+		floatingLength = types.Int64Value(role.FloatingLength)
+	}
+
+	return types.StringValue(grantType), grantStart, grantEnd, floatingLength
+}
+
+func flattenGrantTime(value string, prior types.String) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		a, errA := time.Parse(time.RFC3339, value)
+		b, errB := time.Parse(time.RFC3339, prior.ValueString())
+		if errA == nil && errB == nil && a.Equal(b) {
+			return prior
+		}
+	}
+	return types.StringValue(value)
+}
+
+// validateRoleGrant checks that the grant attributes match the grant type.
+// Unknown values are skipped as they are validated again at apply time.
+// attrPath maps an attribute name to the path errors are reported at.
+func validateRoleGrant(grantType, grantStart, grantEnd types.String, floatingLength types.Int64, attrPath func(string) path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if grantType.IsUnknown() {
+		return diags
+	}
+
+	kind := grantType.ValueString()
+	if kind == "" {
+		kind = roleGrantPermanent
+	}
+
+	hasTime := (!grantStart.IsNull() && !grantStart.IsUnknown()) || (!grantEnd.IsNull() && !grantEnd.IsUnknown())
+	hasLength := !floatingLength.IsNull() && !floatingLength.IsUnknown()
+
+	switch kind {
+	case roleGrantPermanent:
+		if hasTime || hasLength {
+			diags.AddAttributeError(attrPath("grant_type"), "Invalid Role Grant",
+				"grant_start, grant_end and floating_length cannot be set for PERMANENT grants")
+		}
+	case roleGrantTimeRestricted:
+		if hasLength {
+			diags.AddAttributeError(attrPath("floating_length"), "Invalid Role Grant",
+				"floating_length can only be set for FLOATING grants")
+		}
+		if grantStart.IsNull() && grantEnd.IsNull() {
+			diags.AddAttributeError(attrPath("grant_end"), "Invalid Role Grant",
+				"TIME_RESTRICTED grants need grant_start, grant_end or both")
+		}
+	case roleGrantFloating:
+		if hasTime {
+			diags.AddAttributeError(attrPath("grant_type"), "Invalid Role Grant",
+				"grant_start and grant_end can only be set for TIME_RESTRICTED grants")
+		}
+		if floatingLength.IsNull() {
+			diags.AddAttributeError(attrPath("floating_length"), "Invalid Role Grant",
+				"FLOATING grants need floating_length")
+		}
+	}
+
+	return diags
+}
+
+// rfc3339Validator checks that a string is an RFC3339 timestamp.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Timestamp",
+			fmt.Sprintf("Expected an RFC3339 timestamp such as 2025-01-02T15:04:05Z, got: %q", req.ConfigValue.ValueString()))
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRoleMemberResource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_role_member.test"
+
+	cfg1 := testAccRoleMemberConfig(suffix, `grant_type = "PERMANENT"`)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccRoleMemberConfig(suffix, `
+  grant_type  = "TIME_RESTRICTED"
+  grant_start = "2030-01-01T00:00:00Z"
+  grant_end   = "2030-01-02T00:00:00Z"`)
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "user_id", "privx_local_user.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "role_id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "grant_type", "PERMANENT"),
+					resource.TestCheckResourceAttrSet(resourceName, "role_name"),
+				),
+			},
+
+			// UPDATE to a time restricted grant
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "grant_type", "TIME_RESTRICTED"),
+					resource.TestCheckResourceAttr(resourceName, "grant_end", "2030-01-02T00:00:00Z"),
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", resourceName)
+					}
+					return rs.Primary.ID, nil
+				},
+				ImportStateVerifyIgnore: []string{"grant_start", "grant_end"},
+			},
+		},
+	})
+}
+
+func TestAccRoleMembersResource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_role_members.test"
+
+	cfg := testAccRoleMembersConfig(suffix)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "privx_role.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "members.*", map[string]string{
+						"grant_type":      "FLOATING",
+						"floating_length": "8",
+					}),
+				),
+			},
+
+			// Refresh-only: ensure Read is stable
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRoleMemberBaseConfig(suffix string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_local_user" "test" {
+  username  = "tf-acc-member-%[1]s"
+  full_name = "Terraform provider Test User"
+  email     = "tf-acc-member-%[1]s@example.com"
+}
+
+resource "privx_access_group" "test" {
+  name    = "tf-acc-member-%[1]s"
+  comment = "temp access group for role member test"
+}
+
+resource "privx_role" "test" {
+  name            = "tf-acc-member-%[1]s"
+  access_group_id = privx_access_group.test.id
+
+  permissions  = ["users-view"]
+  permit_agent = false
+
+  source_rules = jsonencode({
+    type  = "GROUP"
+    match = "ANY"
+    rules = []
+  })
+}
+`, suffix)
+}
+
+func testAccRoleMemberConfig(suffix, grant string) string {
+	return testAccRoleMemberBaseConfig(suffix) + fmt.Sprintf(`
+resource "privx_role_member" "test" {
+  user_id = privx_local_user.test.id
+  role_id = privx_role.test.id
+  %s
+}
+`, grant)
+}
+
+func testAccRoleMembersConfig(suffix string) string {
+	return testAccRoleMemberBaseConfig(suffix) + `
+resource "privx_role_members" "test" {
+  role_id = privx_role.test.id
+
+  members = [
+    {
+      user_id         = privx_local_user.test.id
+      grant_type      = "FLOATING"
+      floating_length = 8
+    },
+  ]
+}
+`
+}
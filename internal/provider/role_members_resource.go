@@ -0,0 +1,485 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleMembersResource{}
+var _ resource.ResourceWithImportState = &RoleMembersResource{}
+var _ resource.ResourceWithValidateConfig = &RoleMembersResource{}
+
+// roleMembersPageSize is the page size used when listing role members.
+const roleMembersPageSize = 100
+
+func NewRoleMembersResource() resource.Resource {
+	return &RoleMembersResource{}
+}
+
+// RoleMembersResource defines the resource implementation.
+type RoleMembersResource struct {
+	client *rolestore.RoleStore
+}
+
+// RoleMembersResourceModel contains the complete set of explicit members of a role.
+type RoleMembersResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	RoleID   types.String `tfsdk:"role_id"`
+	RoleName types.String `tfsdk:"role_name"`
+	Members  types.Set    `tfsdk:"members"`
+}
+
+// RoleMembersMemberModel is one explicit role grant.
+type RoleMembersMemberModel struct {
+	UserID         types.String `tfsdk:"user_id"`
+	GrantType      types.String `tfsdk:"grant_type"`
+	GrantStart     types.String `tfsdk:"grant_start"`
+	GrantEnd       types.String `tfsdk:"grant_end"`
+	FloatingLength types.Int64  `tfsdk:"floating_length"`
+}
+
+var roleMembersMemberAttrTypes = map[string]attr.Type{
+	"user_id":         types.StringType,
+	"grant_type":      types.StringType,
+	"grant_start":     types.StringType,
+	"grant_end":       types.StringType,
+	"floating_length": types.Int64Type,
+}
+
+func (r *RoleMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_members"
+}
+
+func (r *RoleMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role members resource. Authoritatively manages the explicit members of a role: " +
+			"explicit grants not listed in `members` are revoked. Members granted through source rules are not affected. " +
+			"Do not combine with `privx_role_member` for the same role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Role members ID (same as role_id)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"members": schema.SetNestedAttribute{
+				MarkdownDescription: "Explicit members of the role",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the user",
+							Required:            true,
+						},
+						"grant_type": schema.StringAttribute{
+							MarkdownDescription: "Grant type, one of `PERMANENT`, `TIME_RESTRICTED` or `FLOATING`",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(roleGrantPermanent),
+							Validators: []validator.String{
+								stringvalidator.OneOf(roleGrantPermanent, roleGrantTimeRestricted, roleGrantFloating),
+							},
+						},
+						"grant_start": schema.StringAttribute{
+							MarkdownDescription: "Start of the grant (RFC3339), only for `TIME_RESTRICTED` grants",
+							Optional:            true,
+							Validators: []validator.String{
+								rfc3339Validator{},
+							},
+						},
+						"grant_end": schema.StringAttribute{
+							MarkdownDescription: "End of the grant (RFC3339), only for `TIME_RESTRICTED` grants",
+							Optional:            true,
+							Validators: []validator.String{
+								rfc3339Validator{},
+							},
+						},
+						"floating_length": schema.Int64Attribute{
+							MarkdownDescription: "Length of the floating grant in hours, required for `FLOATING` grants",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleMembersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleMembersResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Members.IsNull() || data.Members.IsUnknown() {
+		return
+	}
+
+	var members []RoleMembersMemberModel
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		if m.UserID.IsUnknown() {
+			continue
+		}
+		if seen[m.UserID.ValueString()] {
+			resp.Diagnostics.AddAttributeError(path.Root("members"), "Duplicate Role Member",
+				fmt.Sprintf("User %s is listed more than once", m.UserID.ValueString()))
+		}
+		seen[m.UserID.ValueString()] = true
+
+		resp.Diagnostics.Append(validateRoleGrant(m.GrantType, m.GrantStart, m.GrantEnd, m.FloatingLength, func(string) path.Path { return path.Root("members") })...)
+	}
+}
+
+func (r *RoleMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = rolestore.New(*connector)
+}
+
+func (r *RoleMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.RoleID
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "created role members resource", map[string]interface{}{
+		"role_id": data.RoleID.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.GetRole(data.RoleID.ValueString()); err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleMembersResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.RoleID
+	resp.Diagnostics.Append(r.read(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := data.RoleID.ValueString()
+	current, err := r.explicitMembers(roleID)
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read members of role %s, got error: %s", roleID, err))
+		return
+	}
+
+	for _, userID := range sortedGrantUserIDs(current) {
+		if err := r.revoke(userID, roleID); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke role %s from user %s, got error: %s", roleID, userID, err))
+			return
+		}
+	}
+}
+
+func (r *RoleMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), req.ID)...)
+}
+
+// apply grants, updates and revokes explicit grants so that the explicit
+// members of the role match the plan.
+func (r *RoleMembersResource) apply(ctx context.Context, data *RoleMembersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var planned []RoleMembersMemberModel
+	diags.Append(data.Members.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	roleID := data.RoleID.ValueString()
+	current, err := r.explicitMembers(roleID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read members of role %s, got error: %s", roleID, err))
+		return diags
+	}
+
+	desired := make(map[string]rolestore.Role, len(planned))
+	for _, m := range planned {
+		desired[m.UserID.ValueString()] = expandRoleGrant(data.RoleID, m.GrantType, m.GrantStart, m.GrantEnd, m.FloatingLength)
+	}
+
+	for _, userID := range sortedGrantUserIDs(desired) {
+		grant := desired[userID]
+		if existing, ok := current[userID]; ok && sameRoleGrant(existing, grant) {
+			continue
+		}
+		tflog.Debug(ctx, "Granting role", map[string]interface{}{
+			"role_id": roleID,
+			"user_id": userID,
+		})
+		if err := r.grant(userID, grant); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to grant role %s to user %s, got error: %s", roleID, userID, err))
+			return diags
+		}
+	}
+
+	for _, userID := range sortedGrantUserIDs(current) {
+		if _, ok := desired[userID]; ok {
+			continue
+		}
+		tflog.Debug(ctx, "Revoking role", map[string]interface{}{
+			"role_id": roleID,
+			"user_id": userID,
+		})
+		if err := r.revoke(userID, roleID); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to revoke role %s from user %s, got error: %s", roleID, userID, err))
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// read refreshes the members from PrivX, keeping the planned spelling of
+// grant times when they denote the same instant.
+func (r *RoleMembersResource) read(ctx context.Context, data *RoleMembersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	prior := make(map[string]RoleMembersMemberModel)
+	if !data.Members.IsNull() && !data.Members.IsUnknown() {
+		var members []RoleMembersMemberModel
+		diags.Append(data.Members.ElementsAs(ctx, &members, false)...)
+		if diags.HasError() {
+			return diags
+		}
+		for _, m := range members {
+			prior[m.UserID.ValueString()] = m
+		}
+	}
+
+	roleID := data.RoleID.ValueString()
+	role, err := r.client.GetRole(roleID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		return diags
+	}
+	data.RoleName = types.StringValue(role.Name)
+
+	current, err := r.explicitMembers(roleID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read members of role %s, got error: %s", roleID, err))
+		return diags
+	}
+
+	members := make([]RoleMembersMemberModel, 0, len(current))
+	for _, userID := range sortedGrantUserIDs(current) {
+		grant := current[userID]
+		p := prior[userID]
+		m := RoleMembersMemberModel{UserID: types.StringValue(userID)}
+		m.GrantType, m.GrantStart, m.GrantEnd, m.FloatingLength = flattenRoleGrant(&grant, p.GrantStart, p.GrantEnd)
+		members = append(members, m)
+	}
+
+	membersValue, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: roleMembersMemberAttrTypes}, members)
+	diags.Append(d...)
+	data.Members = membersValue
+
+	return diags
+}
+
+// explicitMembers returns the explicit grants of a role keyed by user ID.
+func (r *RoleMembersResource) explicitMembers(roleID string) (map[string]rolestore.Role, error) {
+	grants := make(map[string]rolestore.Role)
+
+	for offset := 0; ; offset += roleMembersPageSize {
+		page, err := r.client.GetRoleMembers(roleID, filters.Paging(offset, roleMembersPageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range page.Items {
+			roles, err := getExplicitUserRoles(r.client, user.ID)
+			if err != nil {
+				return nil, err
+			}
+			if role := findRole(roles, roleID); role != nil {
+				grants[user.ID] = *role
+			}
+		}
+
+		if len(page.Items) < roleMembersPageSize || offset+len(page.Items) >= page.Count {
+			break
+		}
+	}
+
+	return grants, nil
+}
+
+func (r *RoleMembersResource) grant(userID string, grant rolestore.Role) error {
+	unlock := lockUserRoles(userID)
+	defer unlock()
+
+	roles, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		return err
+	}
+	if existing := findRole(roles, grant.ID); existing != nil {
+		*existing = grant
+	} else {
+		roles = append(roles, grant)
+	}
+	return r.client.UpdateUserRoles(userID, roles)
+}
+
+func (r *RoleMembersResource) revoke(userID, roleID string) error {
+	unlock := lockUserRoles(userID)
+	defer unlock()
+
+	roles, err := getExplicitUserRoles(r.client, userID)
+	if err != nil {
+		return err
+	}
+	remaining, removed := removeRole(roles, roleID)
+	if !removed {
+		return nil
+	}
+	return r.client.UpdateUserRoles(userID, remaining)
+}
+
+// sameRoleGrant reports whether two grants of the same role are equivalent.
+func sameRoleGrant(a, b rolestore.Role) bool {
+	typeA, startA, endA, lengthA := flattenRoleGrant(&a, types.StringNull(), types.StringNull())
+	typeB, startB, endB, lengthB := flattenRoleGrant(&b, startA, endA)
+	return typeA.Equal(typeB) && startA.Equal(startB) && endA.Equal(endB) && lengthA.Equal(lengthB)
+}
+
+func sortedGrantUserIDs(m map[string]rolestore.Role) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
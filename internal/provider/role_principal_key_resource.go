@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RolePrincipalKeyResource{}
+var _ resource.ResourceWithImportState = &RolePrincipalKeyResource{}
+
+// principalKeyTimeout is how long to wait for PrivX to generate a principal key.
+const principalKeyTimeout = 30 * time.Second
+
+func NewRolePrincipalKeyResource() resource.Resource {
+	return &RolePrincipalKeyResource{}
+}
+
+// RolePrincipalKeyResource defines the resource implementation.
+type RolePrincipalKeyResource struct {
+	client *rolestore.RoleStore
+}
+
+// RolePrincipalKeyResourceModel contains a role principal key.
+type RolePrincipalKeyResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	RoleID            types.String `tfsdk:"role_id"`
+	KeyID             types.String `tfsdk:"key_id"`
+	PrivateKey        types.String `tfsdk:"private_key"`
+	RotateTrigger     types.String `tfsdk:"rotate_trigger"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	Algorithm         types.String `tfsdk:"algorithm"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+	FingerprintMD5    types.String `tfsdk:"fingerprint_md5"`
+}
+
+func (r *RolePrincipalKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_principal_key"
+}
+
+func (r *RolePrincipalKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role principal key resource. Generates a principal key for a role, or imports an existing private key of any algorithm " +
+			"(RSA, ECDSA or Ed25519). Changing `rotate_trigger` replaces the key; use `create_before_destroy` to keep the old key until the new one exists.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Principal key ID in the form `<role_id>/<key_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role the key belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "Principal key ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "PEM or OpenSSH encoded private key to import (write-only). If not set, PrivX generates the key.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value; changing it rotates the key by replacing it with a new one",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "Public key in OpenSSH authorized_keys format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Key algorithm, e.g. `ssh-rsa`, `ecdsa-sha2-nistp256` or `ssh-ed25519`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 fingerprint of the public key, as printed by `ssh-keygen -l`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				MarkdownDescription: "Legacy MD5 fingerprint of the public key, as printed by `ssh-keygen -l -E md5`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RolePrincipalKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = rolestore.New(*connector)
+}
+
+func (r *RolePrincipalKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RolePrincipalKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := data.RoleID.ValueString()
+
+	var keyID string
+	if data.PrivateKey.ValueString() != "" {
+		identifier, err := r.client.ImportPrincipalKey(roleID, rolestore.RolePrincipalKeyImport{
+			PrivateKey: data.PrivateKey.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Resource",
+				"An unexpected error occurred while attempting to import the principal key.\n"+
+					err.Error(),
+			)
+			return
+		}
+		keyID = identifier.ID
+	} else {
+		identifier, err := r.client.CreatePrincipalKey(roleID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Resource",
+				"An unexpected error occurred while attempting to create the resource.\n"+
+					err.Error(),
+			)
+			return
+		}
+		keyID = identifier.ID
+	}
+
+	// Save the key before waiting for it so that a failed wait taints it and
+	// the key is deleted from the role instead of being left behind.
+	data.ID = types.StringValue(roleID + "/" + keyID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := waitForPrincipalKey(ctx, r.client, roleID, keyID, principalKeyTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read principal key %s of role %s, got error: %s", keyID, roleID, err))
+		return
+	}
+
+	if err := data.setPublicKey(key); err != nil {
+		resp.Diagnostics.AddError("Invalid Public Key", fmt.Sprintf("Unable to parse principal key %s of role %s: %s", keyID, roleID, err))
+		return
+	}
+
+	tflog.Debug(ctx, "created role principal key resource", map[string]interface{}{
+		"id":        data.ID.ValueString(),
+		"algorithm": data.Algorithm.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RolePrincipalKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RolePrincipalKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := r.client.GetPrincipalKeys(data.RoleID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read principal keys of role %s, got error: %s", data.RoleID.ValueString(), err))
+		return
+	}
+
+	var key *rolestore.RolePrincipalKey
+	for i := range keys.Items {
+		if keys.Items[i].ID == data.KeyID.ValueString() {
+			key = &keys.Items[i]
+			break
+		}
+	}
+	if key == nil {
+		tflog.Info(ctx, "Principal key no longer exists, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := data.setPublicKey(*key); err != nil {
+		resp.Diagnostics.AddError("Invalid Public Key", fmt.Sprintf("Unable to parse principal key %s: %s", data.ID.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is a no-op: every configurable attribute requires replacement.
+func (r *RolePrincipalKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RolePrincipalKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RolePrincipalKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RolePrincipalKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeletePrincipalKey(data.RoleID.ValueString(), data.KeyID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete principal key %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *RolePrincipalKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleID, keyID, ok := strings.Cut(req.ID, "/")
+	if !ok || roleID == "" || keyID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected import id in the form <role_id>/<key_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyID)...)
+}
+
+func (data *RolePrincipalKeyResourceModel) setPublicKey(key rolestore.RolePrincipalKey) error {
+	info, err := parseSSHPublicKey(key.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	data.KeyID = types.StringValue(key.ID)
+	data.PublicKey = types.StringValue(strings.TrimSpace(key.PublicKey))
+	data.Algorithm = types.StringValue(info.Algorithm)
+	data.FingerprintSHA256 = types.StringValue(info.FingerprintSHA256)
+	data.FingerprintMD5 = types.StringValue(info.FingerprintMD5)
+	return nil
+}
+
+// sshPublicKeyInfo holds the details derived from a public key.
+type sshPublicKeyInfo struct {
+	Algorithm         string
+	FingerprintSHA256 string
+	FingerprintMD5    string
+}
+
+func parseSSHPublicKey(publicKey string) (sshPublicKeyInfo, error) {
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return sshPublicKeyInfo{}, err
+	}
+
+	return sshPublicKeyInfo{
+		Algorithm:         parsed.Type(),
+		FingerprintSHA256: ssh.FingerprintSHA256(parsed),
+		FingerprintMD5:    "MD5:" + ssh.FingerprintLegacyMD5(parsed),
+	}, nil
+}
+
+// waitForPrincipalKey polls a principal key until PrivX has generated its
+// public key. Any key algorithm is accepted.
+func waitForPrincipalKey(ctx context.Context, client *rolestore.RoleStore, roleID, keyID string, timeout time.Duration) (rolestore.RolePrincipalKey, error) {
+	startTime := time.Now()
+	for {
+		key, err := client.GetPrincipalKey(roleID, keyID)
+		if err != nil {
+			return key, err
+		}
+
+		if strings.TrimSpace(key.PublicKey) != "" {
+			return key, nil
+		}
+		if time.Since(startTime) > timeout {
+			return key, fmt.Errorf("public key was not generated within %s", timeout)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for public keys to be generated (%s timeout)", timeout))
+		select {
+		case <-ctx.Done():
+			return key, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRolePrincipalKeyResource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	resourceName := "privx_role_principal_key.test"
+
+	cfg1 := testAccRolePrincipalKeyConfig(suffix, "1")
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccRolePrincipalKeyConfig(suffix, "2")
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	var firstKeyID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "key_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "algorithm"),
+					resource.TestMatchResourceAttr(resourceName, "fingerprint_sha256", regexp.MustCompile(`^SHA256:`)),
+					resource.TestMatchResourceAttr(resourceName, "fingerprint_md5", regexp.MustCompile(`^MD5:`)),
+					func(s *terraform.State) error {
+						firstKeyID = s.RootModule().Resources[resourceName].Primary.Attributes["key_id"]
+						return nil
+					},
+				),
+			},
+
+			// ROTATE
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.Attributes["key_id"]; id == firstKeyID {
+							return fmt.Errorf("principal key was not rotated, key_id is still %s", id)
+						}
+						return nil
+					},
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotate_trigger"},
+			},
+		},
+	})
+}
+
+func testAccRolePrincipalKeyConfig(suffix, trigger string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name    = "tf-acc-pkey-%[1]s"
+  comment = "temp access group for principal key test"
+}
+
+resource "privx_role" "test" {
+  name            = "tf-acc-pkey-%[1]s"
+  access_group_id = privx_access_group.test.id
+  permissions     = ["users-view"]
+}
+
+resource "privx_role_principal_key" "test" {
+  role_id        = privx_role.test.id
+  rotate_trigger = %[2]q
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, suffix, trigger)
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RolePrincipalKeysDataSource{}
+
+func NewRolePrincipalKeysDataSource() datasource.DataSource {
+	return &RolePrincipalKeysDataSource{}
+}
+
+// RolePrincipalKeysDataSource defines the data source implementation.
+type RolePrincipalKeysDataSource struct {
+	client *rolestore.RoleStore
+}
+
+// RolePrincipalKeysDataSourceModel describes the data source data model.
+type RolePrincipalKeysDataSourceModel struct {
+	ID             types.String                `tfsdk:"id"`
+	RoleID         types.String                `tfsdk:"role_id"`
+	Keys           []RolePrincipalKeyItemModel `tfsdk:"keys"`
+	AuthorizedKeys types.String                `tfsdk:"authorized_keys"`
+}
+
+// RolePrincipalKeyItemModel is one principal key of a role.
+type RolePrincipalKeyItemModel struct {
+	ID                types.String `tfsdk:"id"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	Algorithm         types.String `tfsdk:"algorithm"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (d *RolePrincipalKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_principal_keys"
+}
+
+func (d *RolePrincipalKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role principal keys data source. Lists the principal public keys of a role for deployment to target hosts.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source ID (same as role_id)",
+				Computed:            true,
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the role",
+				Required:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "Principal keys of the role",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Principal key ID",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "Public key in OpenSSH format",
+							Computed:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "Key algorithm",
+							Computed:            true,
+						},
+						"fingerprint_sha256": schema.StringAttribute{
+							MarkdownDescription: "SHA256 fingerprint of the key",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"authorized_keys": schema.StringAttribute{
+				MarkdownDescription: "Public keys of the role, one per line, in `authorized_keys` format",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RolePrincipalKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = rolestore.New(*connector)
+}
+
+func (d *RolePrincipalKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolePrincipalKeysDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleID := data.RoleID.ValueString()
+	keys, err := d.client.GetPrincipalKeys(roleID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list principal keys of role %s, got error: %s", roleID, err))
+		return
+	}
+
+	var lines []string
+	data.Keys = []RolePrincipalKeyItemModel{}
+	for _, key := range keys.Items {
+		item := RolePrincipalKeyItemModel{
+			ID:                types.StringValue(key.ID),
+			PublicKey:         types.StringValue(key.PublicKey),
+			Algorithm:         types.StringValue(""),
+			FingerprintSHA256: types.StringValue(""),
+		}
+		if info, err := parseSSHPublicKey(key.PublicKey); err == nil {
+			item.Algorithm = types.StringValue(info.Algorithm)
+			item.FingerprintSHA256 = types.StringValue(info.FingerprintSHA256)
+		}
+		data.Keys = append(data.Keys, item)
+
+		if line := strings.TrimSpace(key.PublicKey); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	authorizedKeys := strings.Join(lines, "\n")
+	if authorizedKeys != "" {
+		authorizedKeys += "\n"
+	}
+	data.AuthorizedKeys = types.StringValue(authorizedKeys)
+	data.ID = types.StringValue(roleID)
+
+	tflog.Debug(ctx, "Storing role principal keys into the state", map[string]interface{}{
+		"role_id": roleID,
+		"count":   len(data.Keys),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRolePrincipalKeysDataSource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	dataSourceName := "data.privx_role_principal_keys.test"
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name    = "tf-acc-rpk-%[1]s"
+  comment = "temp access group for role principal keys test"
+}
+
+resource "privx_role" "test" {
+  name            = "tf-acc-rpk-%[1]s"
+  access_group_id = privx_access_group.test.id
+
+  permissions  = ["users-view"]
+  permit_agent = false
+
+  source_rules = jsonencode({
+    type  = "GROUP"
+    match = "ANY"
+    rules = []
+  })
+}
+
+resource "privx_role_principal_key" "test" {
+  role_id = privx_role.test.id
+}
+
+data "privx_role_principal_keys" "test" {
+  role_id = privx_role.test.id
+
+  depends_on = [privx_role_principal_key.test]
+}
+`, suffix)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "privx_role.test", "id"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "keys.*.id", "privx_role_principal_key.test", "key_id"),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "keys.*.fingerprint_sha256", "privx_role_principal_key.test", "fingerprint_sha256"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "authorized_keys"),
+				),
+			},
+		},
+	})
+}
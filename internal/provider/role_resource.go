@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"terraform-provider-privx/internal/utils"
 	"time"
 
@@ -35,19 +34,22 @@ func NewRoleResource() resource.Resource {
 
 // RoleResource defines the resource implementation.
 type RoleResource struct {
-	client *rolestore.RoleStore
+	client    *rolestore.RoleStore
+	connector restapi.Connector
 }
 
 // Role contains PrivX role information.
 type RoleResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Comment       types.String `tfsdk:"comment"`
-	AccessGroupID types.String `tfsdk:"access_group_id"`
-	Permissions   types.Set    `tfsdk:"permissions"`
-	PublicKey     types.Set    `tfsdk:"principal_public_key_strings"`
-	PermitAgent   types.Bool   `tfsdk:"permit_agent"`
-	SourceRule    types.String `tfsdk:"source_rules"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Comment            types.String `tfsdk:"comment"`
+	AccessGroupID      types.String `tfsdk:"access_group_id"`
+	Permissions        types.Set    `tfsdk:"permissions"`
+	PublicKey          types.Set    `tfsdk:"principal_public_key_strings"`
+	PermitAgent        types.Bool   `tfsdk:"permit_agent"`
+	SourceRule         types.String `tfsdk:"source_rules"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,6 +68,7 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the role",
 				Required:            true,
@@ -158,6 +161,7 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             stringdefault.StaticString(`{"type":"GROUP","match":"ANY","rules":[]}`),
 			},
+			"adopt_existing": adoptExistingAttribute("name"),
 		},
 	}
 }
@@ -182,6 +186,7 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	})
 
 	r.client = rolestore.New(*connector)
+	r.connector = *connector
 }
 
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -233,6 +238,34 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	tflog.Debug(ctx, fmt.Sprintf("rolestore.Role model used: %+v", role))
 
+	if data.AdoptExisting.ValueBool() {
+		existing, err := findRoleByName(r.client, role.Name)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up existing role %q, got error: %s", role.Name, err))
+			return
+		}
+		if existing != nil {
+			// Keep the principal keys of the adopted role.
+			role.ID = existing.ID
+			role.PrincipalPublicKeys = existing.PrincipalPublicKeys
+			if err := r.client.UpdateRole(existing.ID, &role); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update adopted role %s, got error: %s", existing.ID, err))
+				return
+			}
+			warnAdopted(ctx, &resp.Diagnostics, "privx_role", role.Name, existing.ID)
+
+			publicKey, diags := types.SetValueFrom(ctx, data.PublicKey.ElementType(ctx), existing.PrincipalPublicKeys)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			data.PublicKey = publicKey
+			data.ID = types.StringValue(existing.ID)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	roleID, err := r.client.CreateRole(&role)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create the role, got error: %s", err))
@@ -245,26 +278,11 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		tflog.Warn(ctx, fmt.Sprintf("Unable to create the principal key for role %s: %s", roleID.ID, err))
 	} else {
 		// Get role public key into state. PrivX takes some time to generate it.
-		timeout := 12 * time.Second
-		startTime := time.Now()
-		for {
-			principalKeyRead, err := r.client.GetPrincipalKey(roleID.ID, principalKeyID.ID)
-
-			if err != nil {
-				tflog.Warn(ctx, fmt.Sprintf("Unable to read the principal key %s for role %s: %s", principalKeyID.ID, roleID.ID, err))
-				break
-			}
-
-			if strings.Contains(principalKeyRead.PublicKey, "ssh-rsa ") {
-				publicKeyData = append(publicKeyData, principalKeyRead.PublicKey)
-				break
-			}
-			if time.Since(startTime) > timeout {
-				tflog.Debug(ctx, "timeout reached")
-				break
-			}
-			time.Sleep(time.Second)
-			tflog.Debug(ctx, fmt.Sprintf("Waiting for public keys to be generated (%s timeout)", timeout))
+		principalKeyRead, err := waitForPrincipalKey(ctx, r.client, roleID.ID, principalKeyID.ID, 12*time.Second)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Unable to read the principal key %s for role %s: %s", principalKeyID.ID, roleID.ID, err))
+		} else {
+			publicKeyData = append(publicKeyData, principalKeyRead.PublicKey)
 		}
 	}
 	publicKey, diags := types.SetValueFrom(ctx, data.PublicKey.ElementType(ctx), publicKeyData)
@@ -304,6 +322,12 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Comment = types.StringValue(role.Comment)
 	data.AccessGroupID = types.StringValue(role.AccessGroupID)
 	data.PermitAgent = types.BoolValue(role.PermitAgent)
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
 
 	permissions, diags := types.SetValueFrom(ctx, data.Permissions.ElementType(ctx), role.Permissions)
 	if diags.HasError() {
@@ -410,6 +434,10 @@ func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if !checkDeletionProtection(r.connector, data.DeletionProtection, "privx_role", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	err := r.client.DeleteRole(data.ID.ValueString())
 	if err != nil {
 		if utils.IsPrivxNotFound(err) {
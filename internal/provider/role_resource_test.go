@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/SSHcom/privx-sdk-go/v2/api/authorizer"
 	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
 	"github.com/SSHcom/privx-sdk-go/v2/oauth"
 	"github.com/SSHcom/privx-sdk-go/v2/restapi"
@@ -119,6 +120,75 @@ func TestAccRoleResource_basicCreateUpdateDelete(t *testing.T) {
 	})
 }
 
+func TestAccRoleResource_adoptExisting(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	roleName := fmt.Sprintf("tf-acc-test-role-adopt-%s", suffix)
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+data "privx_access_group" "ag" {
+  name = "Default"
+}
+
+resource "privx_role" "test" {
+  name            = %q
+  access_group_id = data.privx_access_group.ag.id
+  comment         = "adopted by acceptance test"
+  permissions     = ["users-view"]
+  adopt_existing  = true
+}
+`, roleName)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	var roleID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+
+		Steps: []resource.TestStep{
+			{
+				// Create the role outside Terraform first
+				PreConfig: func() {
+					conn := testAccPrivxConnectorFromSDKEnv()
+					group, err := findAccessGroupByName(authorizer.New(conn), "Default")
+					if err != nil || group == nil {
+						t.Fatalf("unable to find the Default access group: %v", err)
+					}
+					created, err := rolestore.New(conn).CreateRole(&rolestore.Role{
+						Name:          roleName,
+						AccessGroupID: group.ID,
+						SourceRules:   rolestore.SourceRule{Type: "GROUP", Match: "ANY", SourceRules: []rolestore.SourceRule{}},
+					})
+					if err != nil {
+						t.Fatalf("CreateRole failed: %v", err)
+					}
+					roleID = created.ID
+				},
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("privx_role.test", "id", &roleID),
+					resource.TestCheckResourceAttr("privx_role.test", "comment", "adopted by acceptance test"),
+					resource.TestCheckTypeSetElemAttr("privx_role.test", "permissions.*", "users-view"),
+					resource.TestCheckResourceAttr("privx_role.test", "adopt_existing", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccRoleWithAccessGroupConfig(groupName, roleName string) string {
 	return fmt.Sprintf(`
 terraform {
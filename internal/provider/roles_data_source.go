@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RolesDataSource{}
+
+// listPageSize is the page size used by the list data sources.
+const listPageSize = 100
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+// RolesDataSource defines the data source implementation.
+type RolesDataSource struct {
+	client *rolestore.RoleStore
+}
+
+// RolesDataSourceModel describes the data source data model.
+type RolesDataSourceModel struct {
+	ID            types.String     `tfsdk:"id"`
+	NameRegex     types.String     `tfsdk:"name_regex"`
+	Tags          []types.String   `tfsdk:"tags"`
+	AccessGroupID types.String     `tfsdk:"access_group_id"`
+	IDs           []types.String   `tfsdk:"ids"`
+	Roles         []RolesItemModel `tfsdk:"roles"`
+}
+
+// RolesItemModel is one role.
+type RolesItemModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	AccessGroupID types.String   `tfsdk:"access_group_id"`
+	Comment       types.String   `tfsdk:"comment"`
+	Tags          []types.String `tfsdk:"tags"`
+	Permissions   []types.String `tfsdk:"permissions"`
+	System        types.Bool     `tfsdk:"system"`
+	PermitAgent   types.Bool     `tfsdk:"permit_agent"`
+}
+
+func (d *RolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Roles data source. Lists roles sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"id":         computed("Data source ID"),
+			"name_regex": nameRegexAttribute("roles"),
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Only roles with all of these tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"access_group_id": schema.StringAttribute{
+				MarkdownDescription: "Only roles in this access group",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching roles",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"roles": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching roles",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":              computed("Role ID"),
+						"name":            computed("Role name"),
+						"access_group_id": computed("Access group of the role"),
+						"comment":         computed("Role comment"),
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "Role tags",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"permissions": schema.ListAttribute{
+							MarkdownDescription: "Permissions granted by the role",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"system": schema.BoolAttribute{
+							MarkdownDescription: "Whether the role is a built-in system role",
+							Computed:            true,
+						},
+						"permit_agent": schema.BoolAttribute{
+							MarkdownDescription: "Whether the role permits agent forwarding",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = rolestore.New(*connector)
+}
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := regexp.MustCompile(data.NameRegex.ValueString())
+	accessGroupID := data.AccessGroupID.ValueString()
+
+	var roles []rolestore.Role
+	for offset := 0; ; offset += listPageSize {
+		page, err := d.client.GetRoles(filters.Paging(offset, listPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list roles, got error: %s", err))
+			return
+		}
+
+		for _, role := range page.Items {
+			if !nameRegex.MatchString(role.Name) || !hasAllTags(role.Tags, data.Tags) {
+				continue
+			}
+			if accessGroupID != "" && role.AccessGroupID != accessGroupID {
+				continue
+			}
+			roles = append(roles, role)
+		}
+
+		if len(page.Items) < listPageSize {
+			break
+		}
+	}
+
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	data.IDs = []types.String{}
+	data.Roles = []RolesItemModel{}
+	for _, role := range roles {
+		data.IDs = append(data.IDs, types.StringValue(role.ID))
+		data.Roles = append(data.Roles, RolesItemModel{
+			ID:            types.StringValue(role.ID),
+			Name:          types.StringValue(role.Name),
+			AccessGroupID: types.StringValue(role.AccessGroupID),
+			Comment:       types.StringValue(role.Comment),
+			Tags:          stringValues(role.Tags),
+			Permissions:   stringValues(role.Permissions),
+			System:        types.BoolValue(role.System),
+			PermitAgent:   types.BoolValue(role.PermitAgent),
+		})
+	}
+
+	data.ID = types.StringValue(strings.Join([]string{
+		data.NameRegex.ValueString(),
+		strings.Join(valueStrings(data.Tags), ","),
+		accessGroupID,
+	}, "/"))
+
+	tflog.Debug(ctx, "Storing roles into the state", map[string]interface{}{
+		"count": len(data.Roles),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// nameRegexAttribute is the name_regex filter shared by the list data sources.
+func nameRegexAttribute(objects string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("Only %s whose name matches this regular expression (RE2 syntax, unanchored)", objects),
+		Optional:            true,
+		Validators: []validator.String{
+			regexpValidator{},
+		},
+	}
+}
+
+// hasAllTags reports whether have contains every tag in want.
+func hasAllTags(have []string, want []types.String) bool {
+	for _, tag := range want {
+		found := false
+		for _, h := range have {
+			if h == tag.ValueString() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func stringValues(values []string) []types.String {
+	result := make([]types.String, len(values))
+	for i, v := range values {
+		result[i] = types.StringValue(v)
+	}
+	return result
+}
+
+func valueStrings(values []types.String) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = v.ValueString()
+	}
+	return result
+}
+
+type regexpValidator struct{}
+
+func (v regexpValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Regular Expression",
+			fmt.Sprintf("Expected a regular expression, got %q: %s", req.ConfigValue.ValueString(), err))
+	}
+}
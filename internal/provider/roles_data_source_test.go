@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccListDataSources(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+
+	cfg := fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "test" {
+  name    = "tf-acc-list-%[1]s"
+  comment = "temp access group for list data sources test"
+}
+
+resource "privx_role" "test" {
+  count = 2
+
+  name            = "tf-acc-list-%[1]s-${count.index}"
+  access_group_id = privx_access_group.test.id
+
+  permissions  = ["users-view"]
+  permit_agent = false
+
+  source_rules = jsonencode({
+    type  = "GROUP"
+    match = "ANY"
+    rules = []
+  })
+}
+
+data "privx_role" "tf" {
+  name = "terraform-provider"
+}
+
+resource "privx_secret" "test" {
+  name = "tf-acc-list-%[1]s"
+
+  data = {
+    password = "not-listed"
+  }
+
+  read_roles = [{
+    id = privx_role.test[0].id
+  }]
+
+  write_roles = [{
+    id = data.privx_role.tf.id
+  }]
+}
+
+data "privx_roles" "test" {
+  name_regex = "^tf-acc-list-%[1]s-"
+
+  depends_on = [privx_role.test]
+}
+
+data "privx_access_groups" "test" {
+  name_regex = "^tf-acc-list-%[1]s$"
+
+  depends_on = [privx_access_group.test]
+}
+
+data "privx_secrets" "test" {
+  name_regex   = "^tf-acc-list-%[1]s$"
+  read_role_id = privx_role.test[0].id
+
+  depends_on = [privx_secret.test]
+}
+
+data "privx_workflows" "all" {}
+
+data "privx_users" "all" {
+  max_results = 10
+}
+`, suffix)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.privx_roles.test", "roles.#", "2"),
+					resource.TestCheckResourceAttrPair("data.privx_roles.test", "roles.0.id", "privx_role.test.0", "id"),
+					resource.TestCheckResourceAttrPair("data.privx_roles.test", "roles.1.id", "privx_role.test.1", "id"),
+					resource.TestCheckResourceAttr("data.privx_access_groups.test", "access_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.privx_secrets.test", "names.#", "1"),
+					resource.TestCheckResourceAttrPair("data.privx_secrets.test", "secrets.0.read_roles.0.id", "privx_role.test.0", "id"),
+					resource.TestCheckNoResourceAttr("data.privx_secrets.test", "secrets.0.data"),
+					resource.TestCheckResourceAttrSet("data.privx_workflows.all", "workflows.#"),
+					resource.TestCheckResourceAttrSet("data.privx_users.all", "users.#"),
+				),
+			},
+		},
+	})
+}
@@ -29,22 +29,24 @@ func NewSecretResource() resource.Resource {
 
 // SecretResource defines the resource implementation.
 type SecretResource struct {
-	client *vault.Vault
+	client    *vault.Vault
+	connector restapi.Connector
 }
 
 // SecretResourceModel contains PrivX secret information.
 type SecretResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	ReadRoles  types.List   `tfsdk:"read_roles"`
-	WriteRoles types.List   `tfsdk:"write_roles"`
-	Data       types.Map    `tfsdk:"data"`
-	OwnerID    types.String `tfsdk:"owner_id"`
-	Created    types.String `tfsdk:"created"`
-	Updated    types.String `tfsdk:"updated"`
-	UpdatedBy  types.String `tfsdk:"updated_by"`
-	Author     types.String `tfsdk:"author"`
-	Path       types.String `tfsdk:"path"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	ReadRoles          types.List   `tfsdk:"read_roles"`
+	WriteRoles         types.List   `tfsdk:"write_roles"`
+	Data               types.Map    `tfsdk:"data"`
+	OwnerID            types.String `tfsdk:"owner_id"`
+	Created            types.String `tfsdk:"created"`
+	Updated            types.String `tfsdk:"updated"`
+	UpdatedBy          types.String `tfsdk:"updated_by"`
+	Author             types.String `tfsdk:"author"`
+	Path               types.String `tfsdk:"path"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 // RoleHandleModel represents a role handle.
@@ -65,6 +67,7 @@ func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Secret ID (same as name)",
 				Computed:            true,
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Secret name (used as identifier)",
 				Required:            true,
@@ -160,6 +163,7 @@ func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 
 	r.client = vault.New(*client)
+	r.connector = *client
 }
 
 func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -280,6 +284,10 @@ func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, res
 		"state": fmt.Sprintf("%+v", data),
 	})
 
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -383,6 +391,10 @@ func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if !checkDeletionProtection(r.connector, data.DeletionProtection, "privx_secret", data.Name.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting secret", map[string]interface{}{
 		"name": data.Name.ValueString(),
 	})
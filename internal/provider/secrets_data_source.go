@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/response"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/api/vault"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SecretsDataSource{}
+
+func NewSecretsDataSource() datasource.DataSource {
+	return &SecretsDataSource{}
+}
+
+// SecretsDataSource defines the data source implementation.
+type SecretsDataSource struct {
+	client *vault.Vault
+}
+
+// SecretsDataSourceModel describes the data source data model.
+type SecretsDataSourceModel struct {
+	ID         types.String       `tfsdk:"id"`
+	NameRegex  types.String       `tfsdk:"name_regex"`
+	OwnerID    types.String       `tfsdk:"owner_id"`
+	ReadRoleID types.String       `tfsdk:"read_role_id"`
+	Query      types.String       `tfsdk:"query"`
+	Names      []types.String     `tfsdk:"names"`
+	Secrets    []SecretsItemModel `tfsdk:"secrets"`
+}
+
+// SecretsItemModel is the metadata of one secret.
+type SecretsItemModel struct {
+	Name       types.String      `tfsdk:"name"`
+	Path       types.String      `tfsdk:"path"`
+	OwnerID    types.String      `tfsdk:"owner_id"`
+	ReadRoles  []RoleHandleModel `tfsdk:"read_roles"`
+	WriteRoles []RoleHandleModel `tfsdk:"write_roles"`
+	Author     types.String      `tfsdk:"author"`
+	Created    types.String      `tfsdk:"created"`
+	Updated    types.String      `tfsdk:"updated"`
+	UpdatedBy  types.String      `tfsdk:"updated_by"`
+}
+
+func (d *SecretsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secrets"
+}
+
+func (d *SecretsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Secrets data source. Lists vault secrets sorted by name. Only metadata is returned, never secret values.",
+		Attributes: map[string]schema.Attribute{
+			"id":         computed("Data source ID"),
+			"name_regex": nameRegexAttribute("secrets"),
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "Only secrets owned by this user ID",
+				Optional:            true,
+			},
+			"read_role_id": schema.StringAttribute{
+				MarkdownDescription: "Only secrets readable by this role",
+				Optional:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Free-text search over the secrets",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Names of the matching secrets",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"secrets": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching secrets",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":        computed("Secret name"),
+						"path":        computed("Secret path"),
+						"owner_id":    computed("ID of the owning user, for personal secrets"),
+						"read_roles":  roleHandleListAttribute("Roles that can read the secret"),
+						"write_roles": roleHandleListAttribute("Roles that can write the secret"),
+						"author":      computed("ID of the user who created the secret"),
+						"created":     computed("Creation time"),
+						"updated":     computed("Last update time"),
+						"updated_by":  computed("ID of the user who last updated the secret"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SecretsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating vault", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = vault.New(*connector)
+}
+
+func (d *SecretsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SecretsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := regexp.MustCompile(data.NameRegex.ValueString())
+	ownerID := data.OwnerID.ValueString()
+	readRoleID := data.ReadRoleID.ValueString()
+	query := data.Query.ValueString()
+
+	var secrets []vault.Secret
+	for offset := 0; ; offset += listPageSize {
+		var page *response.ResultSet[vault.Secret]
+		var err error
+		if ownerID == "" && query == "" {
+			page, err = d.client.GetSecrets(filters.Paging(offset, listPageSize))
+		} else {
+			search := vault.SecretSearch{Keywords: query, Limit: listPageSize, Offset: offset}
+			if ownerID != "" {
+				search.OwnerIDs = []string{ownerID}
+			}
+			page, err = d.client.SearchSecrets(search, filters.Paging(offset, listPageSize))
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list secrets, got error: %s", err))
+			return
+		}
+
+		for _, secret := range page.Items {
+			if !nameRegex.MatchString(secret.Name) || (readRoleID != "" && !hasRoleHandle(secret.ReadRoles, readRoleID)) {
+				continue
+			}
+			secrets = append(secrets, secret)
+		}
+
+		if len(page.Items) < listPageSize {
+			break
+		}
+	}
+
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+
+	data.Names = []types.String{}
+	data.Secrets = []SecretsItemModel{}
+	for _, secret := range secrets {
+		data.Names = append(data.Names, types.StringValue(secret.Name))
+		data.Secrets = append(data.Secrets, SecretsItemModel{
+			Name:       types.StringValue(secret.Name),
+			Path:       types.StringValue(secret.Path),
+			OwnerID:    types.StringValue(secret.OwnerID),
+			ReadRoles:  roleHandleModels(secret.ReadRoles),
+			WriteRoles: roleHandleModels(secret.WriteRoles),
+			Author:     types.StringValue(secret.Author),
+			Created:    types.StringValue(secret.Created.UTC().Format(time.RFC3339)),
+			Updated:    types.StringValue(secret.Updated.UTC().Format(time.RFC3339)),
+			UpdatedBy:  types.StringValue(secret.UpdatedBy),
+		})
+	}
+
+	data.ID = types.StringValue(strings.Join([]string{data.NameRegex.ValueString(), ownerID, readRoleID, query}, "/"))
+
+	tflog.Debug(ctx, "Storing secrets into the state", map[string]interface{}{
+		"count": len(data.Secrets),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// roleHandleListAttribute is a computed list of role id/name pairs.
+func roleHandleListAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Role ID",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Role name",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func roleHandleModels(roles []rolestore.RoleHandle) []RoleHandleModel {
+	result := make([]RoleHandleModel, len(roles))
+	for i, role := range roles {
+		result[i] = RoleHandleModel{ID: types.StringValue(role.ID), Name: types.StringValue(role.Name)}
+	}
+	return result
+}
+
+func hasRoleHandle(roles []rolestore.RoleHandle, roleID string) bool {
+	for _, role := range roles {
+		if role.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// NewServer returns the protocol server of the provider. It logs a summary
+// of the PrivX API requests made during every operation that can call the
+// API when the operation ends.
+func NewServer(version string) func() tfprotov6.ProviderServer {
+	p := New(version)().(*privxProvider)
+	server := providerserver.NewProtocol6(p)
+
+	return func() tfprotov6.ProviderServer {
+		return &metricsServer{ProviderServer: server(), provider: p}
+	}
+}
+
+type metricsServer struct {
+	tfprotov6.ProviderServer
+	provider *privxProvider
+}
+
+// trackMetrics starts counting the requests of one operation. The returned
+// function logs them and must be called when the operation ends.
+func (s *metricsServer) trackMetrics(ctx context.Context, operation, typeName string) func() {
+	metrics, stop := s.provider.metrics.Track()
+
+	return func() {
+		stop()
+
+		requests := metrics.Requests()
+		if requests == 0 {
+			return
+		}
+
+		tflog.Debug(ctx, "PrivX API request metrics", map[string]interface{}{
+			"operation": operation,
+			"type_name": typeName,
+			"requests":  requests,
+			"endpoints": metrics.Summary(),
+		})
+	}
+}
+
+func (s *metricsServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	defer s.trackMetrics(ctx, "ReadDataSource", req.TypeName)()
+	return s.ProviderServer.ReadDataSource(ctx, req)
+}
+
+func (s *metricsServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	defer s.trackMetrics(ctx, "ReadResource", req.TypeName)()
+	return s.ProviderServer.ReadResource(ctx, req)
+}
+
+func (s *metricsServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	defer s.trackMetrics(ctx, "PlanResourceChange", req.TypeName)()
+	return s.ProviderServer.PlanResourceChange(ctx, req)
+}
+
+// ApplyResourceChange refuses every create, update and delete in read-only
+// mode before the resource runs, so that not even the lookups a resource
+// makes before its first write reach PrivX. The connector guard still
+// refuses writes as a backstop.
+func (s *metricsServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	defer s.trackMetrics(ctx, "ApplyResourceChange", req.TypeName)()
+
+	if s.provider.readOnly {
+		return &tfprotov6.ApplyResourceChangeResponse{
+			NewState: req.PriorState,
+			Diagnostics: []*tfprotov6.Diagnostic{{
+				Severity: tfprotov6.DiagnosticSeverityError,
+				Summary:  "Read-Only Mode",
+				Detail:   fmt.Sprintf("The provider is in read-only mode (read_only or PRIVX_READ_ONLY), refusing to change %s.", req.TypeName),
+			}},
+		}, nil
+	}
+	return s.ProviderServer.ApplyResourceChange(ctx, req)
+}
+
+func (s *metricsServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	defer s.trackMetrics(ctx, "ImportResourceState", req.TypeName)()
+	return s.ProviderServer.ImportResourceState(ctx, req)
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// objectValue returns a value of an object type with the given attributes
+// set and all others null.
+func objectValue(t *testing.T, typ tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	object, ok := typ.(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected an object type, got %s", typ)
+	}
+	attrs := make(map[string]tftypes.Value, len(object.AttributeTypes))
+	for name, attrType := range object.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(typ, attrs)
+}
+
+func dynamicValue(t *testing.T, typ tftypes.Type, value tftypes.Value) *tfprotov6.DynamicValue {
+	dv, err := tfprotov6.NewDynamicValue(typ, value)
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+	return &dv
+}
+
+// applyHostCreate configures the provider against a fake PrivX API, applies
+// the creation of a privx_host that adopts an existing host, and returns the
+// response and the number of API requests made.
+func applyHostCreate(t *testing.T, readOnly bool) (*tfprotov6.ApplyResourceChangeResponse, int64) {
+	var requests int64
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer api.Close()
+
+	ctx := context.Background()
+	server := NewServer("test")()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+
+	providerType := schemas.Provider.ValueType()
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: dynamicValue(t, providerType, objectValue(t, providerType, map[string]tftypes.Value{
+			"api_base_url":     tftypes.NewValue(tftypes.String, api.URL),
+			"api_bearer_token": tftypes.NewValue(tftypes.String, "token"),
+			"read_only":        tftypes.NewValue(tftypes.Bool, readOnly),
+		})),
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %v", err)
+	}
+	for _, d := range configured.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("ConfigureProvider: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	hostType := schemas.ResourceSchemas["privx_host"].ValueType()
+	planned := objectValue(t, hostType, map[string]tftypes.Value{
+		"common_name":     tftypes.NewValue(tftypes.String, "tf-read-only"),
+		"access_group_id": tftypes.NewValue(tftypes.String, "ag"),
+		"adopt_existing":  tftypes.NewValue(tftypes.Bool, true),
+	})
+	applied, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "privx_host",
+		PriorState:   dynamicValue(t, hostType, tftypes.NewValue(hostType, nil)),
+		PlannedState: dynamicValue(t, hostType, planned),
+		Config:       dynamicValue(t, hostType, planned),
+	})
+	if err != nil {
+		t.Fatalf("ApplyResourceChange: %v", err)
+	}
+	return applied, atomic.LoadInt64(&requests)
+}
+
+func TestReadOnlyApplySendsNoRequests(t *testing.T) {
+	applied, requests := applyHostCreate(t, true)
+
+	if requests != 0 {
+		t.Errorf("read-only apply made %d API requests, want 0", requests)
+	}
+	if len(applied.Diagnostics) != 1 || applied.Diagnostics[0].Summary != "Read-Only Mode" {
+		t.Errorf("got diagnostics %+v, want a read-only mode error", applied.Diagnostics)
+	}
+
+	// Without read-only mode the same create looks up the host to adopt.
+	if _, requests := applyHostCreate(t, false); requests == 0 {
+		t.Error("apply made no API requests, the read-only test does not exercise the lookup")
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/settings"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SettingsDataSource{}
+
+func NewSettingsDataSource() datasource.DataSource {
+	return &SettingsDataSource{}
+}
+
+// SettingsDataSource defines the data source implementation.
+type SettingsDataSource struct {
+	client *settings.Settings
+}
+
+// SettingsDataSourceModel describes the data source data model.
+type SettingsDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Scope        types.String `tfsdk:"scope"`
+	Section      types.String `tfsdk:"section"`
+	Settings     types.Map    `tfsdk:"settings"`
+	SettingsJSON types.String `tfsdk:"settings_json"`
+}
+
+func (d *SettingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (d *SettingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Settings data source. Reads the settings of one PrivX service scope, optionally limited to a single section.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Settings ID (scope, or scope/section when `section` is set)",
+				Computed:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Settings scope, i.e. the PrivX service name such as `rolestore`, `connection-manager` or `trail-index`",
+				Required:            true,
+			},
+			"section": schema.StringAttribute{
+				MarkdownDescription: "Only read this settings section",
+				Optional:            true,
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "Settings keyed by `section.key`, rendered as strings in the same format as the `privx_settings` resource",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"settings_json": schema.StringAttribute{
+				MarkdownDescription: "Settings returned by PrivX, JSON encoded",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *SettingsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating settings", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = settings.New(*connector)
+}
+
+func (d *SettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SettingsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := data.Scope.ValueString()
+	scopeSettings := make(map[string]interface{})
+	if section := data.Section.ValueString(); section != "" {
+		raw, err := d.client.GetSectionSettings(scope, section)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read settings section %s/%s, got error: %s", scope, section, err))
+			return
+		}
+		values := make(map[string]interface{})
+		if raw != nil && len(*raw) > 0 {
+			if err := json.Unmarshal(*raw, &values); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to decode settings section %s/%s, got error: %s", scope, section, err))
+				return
+			}
+		}
+		scopeSettings[section] = values
+		data.ID = types.StringValue(scope + "/" + section)
+	} else {
+		all, err := getScopeSettings(d.client, scope)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read settings for scope %s, got error: %s", scope, err))
+			return
+		}
+		scopeSettings = all
+		data.ID = types.StringValue(scope)
+	}
+
+	settingsValue, diags := types.MapValueFrom(ctx, types.StringType, flattenSettings(scopeSettings))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Settings = settingsValue
+
+	raw, err := json.Marshal(scopeSettings)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode settings, got error: %s", err))
+		return
+	}
+	data.SettingsJSON = types.StringValue(string(raw))
+
+	tflog.Debug(ctx, "Storing settings into the state", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
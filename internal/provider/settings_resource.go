@@ -0,0 +1,434 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/settings"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SettingsResource{}
+var _ resource.ResourceWithImportState = &SettingsResource{}
+
+func NewSettingsResource() resource.Resource {
+	return &SettingsResource{}
+}
+
+// SettingsResource defines the resource implementation.
+type SettingsResource struct {
+	client *settings.Settings
+}
+
+// SettingsResourceModel contains the PrivX settings owned by Terraform in one scope.
+type SettingsResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Scope    types.String `tfsdk:"scope"`
+	Settings types.Map    `tfsdk:"settings"`
+}
+
+func (r *SettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (r *SettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Settings resource. Manages a subset of the settings in one PrivX service scope. " +
+			"Only the keys listed in `settings` are written and compared; all other settings in the scope are left untouched. " +
+			"Removing a key from `settings`, or destroying the resource, stops managing it but does not reset its value in PrivX. " +
+			"Import with the scope as ID to manage no keys yet, or with `scope:section.key,section.key` to import the listed keys with their current values.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Settings ID (same as scope)",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Settings scope, i.e. the PrivX service name such as `rolestore`, `connection-manager` or `trail-index`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"settings": schema.MapAttribute{
+				MarkdownDescription: "Settings keyed by `section.key`. PrivX settings have no published per-scope schema, so values are given as strings " +
+					"and converted to the type of the value the setting currently has in PrivX: strings are written as is, " +
+					"`true`/`false` for booleans, decimal numbers for numbers and JSON for lists and objects. " +
+					"A setting without a current value is written as JSON when the string is valid JSON and as a string otherwise. " +
+					"A value that does not convert to the current type fails the apply. " +
+					"Read keeps the configured spelling when it denotes the same value, e.g. `30.0` for `30`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(settingsKeyRegexp, "must be in the form section.key"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *SettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating settings", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = settings.New(*connector)
+}
+
+func (r *SettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned := make(map[string]string)
+	resp.Diagnostics.Append(data.Settings.ElementsAs(ctx, &owned, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.mergeSettings(ctx, data.Scope.ValueString(), owned); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.ID = data.Scope
+	resp.Diagnostics.Append(r.readOwned(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "created settings resource", map[string]interface{}{
+		"scope": data.Scope.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readOwned(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SettingsResourceModel
+	var state SettingsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned := make(map[string]string)
+	current := make(map[string]string)
+	resp.Diagnostics.Append(plan.Settings.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Settings.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only write keys whose value actually changed.
+	changed := make(map[string]string)
+	for key, value := range planned {
+		if old, ok := current[key]; !ok || old != value {
+			changed[key] = value
+		}
+	}
+
+	if len(changed) > 0 {
+		if err := r.mergeSettings(ctx, plan.Scope.ValueString(), changed); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update settings, got error: %s", err))
+			return
+		}
+	}
+
+	plan.ID = plan.Scope
+	resp.Diagnostics.Append(r.readOwned(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Settings cannot be deleted, only released from Terraform management.
+	tflog.Info(ctx, "Removing settings from state, values in PrivX are left unchanged", map[string]interface{}{
+		"scope": data.Scope.ValueString(),
+	})
+}
+
+// ImportState accepts either a scope, which imports no keys, or
+// scope:section.key,section.key to import the listed keys with their
+// current values.
+func (r *SettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, list, _ := strings.Cut(req.ID, ":")
+
+	owned := map[string]string{}
+	if list != "" {
+		for _, key := range strings.Split(list, ",") {
+			key = strings.TrimSpace(key)
+			if !settingsKeyRegexp.MatchString(key) {
+				resp.Diagnostics.AddError(
+					"Invalid import ID",
+					fmt.Sprintf("Expected scope or scope:section.key,section.key, got %q: %q is not in the form section.key.", req.ID, key),
+				)
+				return
+			}
+			// Read replaces the empty value with the value in PrivX.
+			owned[key] = ""
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("settings"), owned)...)
+}
+
+// mergeSettings writes the given section.key values on top of the current
+// settings, one section at a time, so that keys not owned by Terraform keep
+// their values.
+func (r *SettingsResource) mergeSettings(ctx context.Context, scope string, owned map[string]string) error {
+	current, err := getScopeSettings(r.client, scope)
+	if err != nil {
+		return fmt.Errorf("unable to read settings for scope %s: %w", scope, err)
+	}
+
+	bySection := make(map[string]map[string]string)
+	for key, value := range owned {
+		section, name, _ := strings.Cut(key, ".")
+		if bySection[section] == nil {
+			bySection[section] = make(map[string]string)
+		}
+		bySection[section][name] = value
+	}
+
+	sections := make([]string, 0, len(bySection))
+	for section := range bySection {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	for _, section := range sections {
+		existing, _ := current[section].(map[string]interface{})
+		if existing == nil {
+			return fmt.Errorf("settings section %s does not exist in scope %s", section, scope)
+		}
+
+		merged := make(map[string]interface{}, len(existing))
+		for k, v := range existing {
+			merged[k] = v
+		}
+		for name, value := range bySection[section] {
+			typed, err := coerceSettingValue(existing[name], value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s.%s: %w", section, name, err)
+			}
+			merged[name] = typed
+		}
+
+		tflog.Debug(ctx, "Updating settings section", map[string]interface{}{
+			"scope":   scope,
+			"section": section,
+		})
+		if err := r.client.UpdateSectionSettings(scope, section, merged); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readOwned refreshes the values of the keys present in the model. Keys that
+// no longer exist in PrivX are dropped so that they show up as a diff.
+func (r *SettingsResource) readOwned(ctx context.Context, data *SettingsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	owned := make(map[string]string)
+	diags.Append(data.Settings.ElementsAs(ctx, &owned, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	current, err := getScopeSettings(r.client, data.Scope.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read settings for scope %s, got error: %s", data.Scope.ValueString(), err))
+		return diags
+	}
+
+	refreshed := make(map[string]string, len(owned))
+	for key, prior := range owned {
+		section, name, _ := strings.Cut(key, ".")
+		values, ok := current[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		// Keep the configured spelling when it denotes the same value,
+		// e.g. "30.0" and "30" for a number.
+		if typed, err := coerceSettingValue(value, prior); err == nil && reflect.DeepEqual(typed, value) {
+			refreshed[key] = prior
+			continue
+		}
+		refreshed[key] = renderSettingValue(value)
+	}
+
+	settingsValue, d := types.MapValueFrom(ctx, types.StringType, refreshed)
+	diags.Append(d...)
+	data.Settings = settingsValue
+
+	return diags
+}
+
+var settingsKeyRegexp = regexp.MustCompile(`^[^.]+\..+$`)
+
+// getScopeSettings returns the settings of a scope keyed by section.
+func getScopeSettings(client *settings.Settings, scope string) (map[string]interface{}, error) {
+	raw, err := client.GetScopeSettings(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	if raw == nil || len(*raw) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(*raw, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// flattenSettings converts section -> key -> value settings into a
+// section.key -> string map.
+func flattenSettings(scopeSettings map[string]interface{}) map[string]string {
+	flat := make(map[string]string)
+	for section, values := range scopeSettings {
+		keys, ok := values.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, value := range keys {
+			flat[section+"."+name] = renderSettingValue(value)
+		}
+	}
+	return flat
+}
+
+func renderSettingValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(raw)
+	}
+}
+
+// coerceSettingValue converts a string value to the type of the current
+// setting value. Settings without a current value are decoded as JSON when
+// possible and kept as strings otherwise.
+func coerceSettingValue(current interface{}, value string) (interface{}, error) {
+	switch current.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.ParseBool(value)
+	case float64:
+		return strconv.ParseFloat(value, 64)
+	case map[string]interface{}, []interface{}:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return nil, fmt.Errorf("expected JSON: %w", err)
+		}
+		return decoded, nil
+	default:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err == nil {
+			return decoded, nil
+		}
+		return value, nil
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// testAccSettingsPreCheck skips unless a setting that is safe to modify has
+// been named, as the test changes live PrivX configuration.
+func testAccSettingsPreCheck(t *testing.T) {
+	if os.Getenv("PRIVX_ACC_SETTINGS_SCOPE") == "" ||
+		os.Getenv("PRIVX_ACC_SETTINGS_KEY") == "" ||
+		os.Getenv("PRIVX_ACC_SETTINGS_VALUE") == "" {
+		t.Skip(
+			"Skipping settings acceptance test. " +
+				"Set PRIVX_ACC_SETTINGS_SCOPE, PRIVX_ACC_SETTINGS_KEY (section.key) and PRIVX_ACC_SETTINGS_VALUE to enable.",
+		)
+	}
+}
+
+func TestAccSettingsResource(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	testAccSettingsPreCheck(t)
+
+	scope := os.Getenv("PRIVX_ACC_SETTINGS_SCOPE")
+	key := os.Getenv("PRIVX_ACC_SETTINGS_KEY")
+	value := os.Getenv("PRIVX_ACC_SETTINGS_VALUE")
+	resourceName := "privx_settings.test"
+
+	cfg := testAccSettingsConfig(scope, key, value)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", scope),
+					resource.TestCheckResourceAttr(resourceName, "settings.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("settings.%s", key), value),
+					resource.TestCheckResourceAttr("data.privx_settings.test", fmt.Sprintf("settings.%s", key), value),
+				),
+			},
+
+			// Refresh-only: unmanaged keys must not show up as drift
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "settings.%", "1"),
+				),
+			},
+
+			// IMPORT the owned key with its current value
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s", scope, key),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSettingsConfig(scope, key, value string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_settings" "test" {
+  scope = %q
+
+  settings = {
+    %q = %q
+  }
+}
+
+data "privx_settings" "test" {
+  scope      = privx_settings.test.scope
+  depends_on = [privx_settings.test]
+}
+`, scope, key, value)
+}
@@ -28,7 +28,8 @@ func NewSourceResource() resource.Resource {
 
 // SourceResource defines the resource implementation.
 type SourceResource struct {
-	client *rolestore.RoleStore
+	client    *rolestore.RoleStore
+	connector restapi.Connector
 }
 
 type (
@@ -59,6 +60,7 @@ type (
 		UsernamePattern     types.List           `tfsdk:"username_pattern"`
 		ExternalUserMapping []*EUMModel          `tfsdk:"external_user_mapping"`
 		OIDCConnection      *OIDCConnectionModel `tfsdk:"oidc_connection"`
+		DeletionProtection  types.Bool           `tfsdk:"deletion_protection"`
 	}
 )
 
@@ -79,6 +81,7 @@ func (r *SourceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Source name",
 				Optional:            true,
@@ -178,6 +181,7 @@ func (r *SourceResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 
 	r.client = rolestore.New(*connector)
+	r.connector = *connector
 }
 
 func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -324,6 +328,10 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	tflog.Info(ctx, fmt.Sprintf("data stored: %+v", data))
 
 	// Save updated data into Terraform state
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -410,6 +418,10 @@ func (r *SourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if !checkDeletionProtection(r.connector, data.DeletionProtection, "privx_source", data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	err := r.client.DeleteSource(data.ID.ValueString())
 	if err != nil {
 		if utils.IsPrivxNotFound(err) {
@@ -0,0 +1,388 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserAuthorizedKeyResource{}
+var _ resource.ResourceWithImportState = &UserAuthorizedKeyResource{}
+
+func NewUserAuthorizedKeyResource() resource.Resource {
+	return &UserAuthorizedKeyResource{}
+}
+
+// UserAuthorizedKeyResource defines the resource implementation.
+type UserAuthorizedKeyResource struct {
+	client *rolestore.RoleStore
+}
+
+// UserAuthorizedKeyResourceModel contains a user's SSH authorized key.
+type UserAuthorizedKeyResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	UserID            types.String `tfsdk:"user_id"`
+	KeyID             types.String `tfsdk:"key_id"`
+	PublicKey         types.String `tfsdk:"public_key"`
+	Name              types.String `tfsdk:"name"`
+	Comment           types.String `tfsdk:"comment"`
+	Source            types.String `tfsdk:"source"`
+	SourceAddress     types.Set    `tfsdk:"source_address"`
+	Algorithm         types.String `tfsdk:"algorithm"`
+	FingerprintSHA256 types.String `tfsdk:"fingerprint_sha256"`
+}
+
+func (r *UserAuthorizedKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_authorized_key"
+}
+
+func (r *UserAuthorizedKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "User authorized key resource. Registers an SSH public key that a user can authenticate to PrivX with.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Authorized key ID in the form `<user_id>/<key_id>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user owning the key",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "Authorized key ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "Public key in OpenSSH authorized_keys format, e.g. `ssh-ed25519 AAAA... user@host`",
+				Required:            true,
+				Validators: []validator.String{
+					sshPublicKeyValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the key",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "A comment describing the key",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Source of the key, e.g. the name of the onboarding system",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_address": schema.SetAttribute{
+				MarkdownDescription: "Source addresses or CIDRs the key may be used from. Empty allows any address.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Key algorithm, e.g. `ssh-rsa`, `ecdsa-sha2-nistp256` or `ssh-ed25519`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA256 fingerprint of the key, as printed by `ssh-keygen -l`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserAuthorizedKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = rolestore.New(*connector)
+}
+
+func (r *UserAuthorizedKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserAuthorizedKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := data.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	identifier, err := r.client.CreateUserAuthorizedKey(userID, key)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(userID + "/" + identifier.ID)
+	data.KeyID = types.StringValue(identifier.ID)
+
+	created, err := r.client.GetUserAuthorizedKey(userID, identifier.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read authorized key, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(data.flatten(ctx, created)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "created user authorized key resource", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserAuthorizedKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserAuthorizedKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.GetUserAuthorizedKey(data.UserID.ValueString(), data.KeyID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			tflog.Info(ctx, "Authorized key no longer exists, removing from state", map[string]interface{}{
+				"id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read authorized key, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserAuthorizedKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserAuthorizedKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := data.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	key.ID = data.KeyID.ValueString()
+
+	userID := data.UserID.ValueString()
+	if err := r.client.UpdateUserAuthorizedKey(userID, key.ID, key); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update authorized key, got error: %s", err))
+		return
+	}
+
+	updated, err := r.client.GetUserAuthorizedKey(userID, key.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read authorized key, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(data.flatten(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserAuthorizedKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserAuthorizedKeyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteUserAuthorizedKey(data.UserID.ValueString(), data.KeyID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete authorized key, got error: %s", err))
+		return
+	}
+}
+
+func (r *UserAuthorizedKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userID, keyID, ok := strings.Cut(req.ID, "/")
+	if !ok || userID == "" || keyID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Expected import id in the form <user_id>/<key_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_id"), keyID)...)
+}
+
+func (data *UserAuthorizedKeyResourceModel) expand(ctx context.Context) (*rolestore.AuthorizedKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	key := &rolestore.AuthorizedKey{
+		UserID:        data.UserID.ValueString(),
+		PublicKey:     strings.TrimSpace(data.PublicKey.ValueString()),
+		Name:          data.Name.ValueString(),
+		Comment:       data.Comment.ValueString(),
+		Source:        data.Source.ValueString(),
+		SourceAddress: []string{},
+	}
+
+	if !data.SourceAddress.IsNull() && !data.SourceAddress.IsUnknown() {
+		diags.Append(data.SourceAddress.ElementsAs(ctx, &key.SourceAddress, false)...)
+	}
+
+	return key, diags
+}
+
+// flatten copies the key into the model. The configured public key is kept
+// when PrivX returns the same key with a different comment or whitespace.
+func (data *UserAuthorizedKeyResourceModel) flatten(ctx context.Context, key *rolestore.AuthorizedKey) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !sameSSHPublicKey(data.PublicKey.ValueString(), key.PublicKey) {
+		data.PublicKey = types.StringValue(key.PublicKey)
+	}
+
+	info, err := parseSSHPublicKey(key.PublicKey)
+	if err != nil {
+		diags.AddError("Invalid Public Key", fmt.Sprintf("Unable to parse authorized key %s: %s", key.ID, err))
+		return diags
+	}
+
+	data.KeyID = types.StringValue(key.ID)
+	data.Name = types.StringValue(key.Name)
+	data.Comment = types.StringValue(key.Comment)
+	data.Source = types.StringValue(key.Source)
+	data.Algorithm = types.StringValue(info.Algorithm)
+	data.FingerprintSHA256 = types.StringValue(info.FingerprintSHA256)
+
+	if len(key.SourceAddress) > 0 || !data.SourceAddress.IsNull() {
+		sourceAddress, d := types.SetValueFrom(ctx, types.StringType, key.SourceAddress)
+		diags.Append(d...)
+		data.SourceAddress = sourceAddress
+	}
+
+	return diags
+}
+
+// sameSSHPublicKey reports whether two authorized_keys lines hold the same key,
+// ignoring comments and options.
+func sameSSHPublicKey(a, b string) bool {
+	keyA, _, _, _, errA := ssh.ParseAuthorizedKey([]byte(a))
+	keyB, _, _, _, errB := ssh.ParseAuthorizedKey([]byte(b))
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return bytes.Equal(keyA.Marshal(), keyB.Marshal())
+}
+
+// sshPublicKeyValidator checks that a string is an OpenSSH public key.
+type sshPublicKeyValidator struct{}
+
+func (v sshPublicKeyValidator) Description(ctx context.Context) string {
+	return "value must be a public key in OpenSSH authorized_keys format"
+}
+
+func (v sshPublicKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sshPublicKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := parseSSHPublicKey(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Public Key",
+			fmt.Sprintf("Expected a public key in OpenSSH authorized_keys format such as \"ssh-ed25519 AAAA... user@host\": %s", err))
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAccUserAuthorizedKeyResource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(6, acctest.CharSetAlphaNum)
+	publicKey := testAccEd25519PublicKey(t)
+	resourceName := "privx_user_authorized_key.test"
+
+	cfg1 := testAccUserAuthorizedKeyConfig(suffix, publicKey, "created by terraform")
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg1)
+
+	cfg2 := testAccUserAuthorizedKeyConfig(suffix, publicKey, "updated by terraform")
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfg2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE
+			{
+				Config: cfg1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "key_id"),
+					resource.TestCheckResourceAttr(resourceName, "algorithm", "ssh-ed25519"),
+					resource.TestCheckResourceAttr(resourceName, "comment", "created by terraform"),
+					resource.TestCheckResourceAttr("data.privx_user_authorized_keys.test", "keys.#", "1"),
+				),
+			},
+
+			// UPDATE
+			{
+				Config: cfg2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "comment", "updated by terraform"),
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccEd25519PublicKey returns a freshly generated Ed25519 public key in
+// authorized_keys format.
+func testAccEd25519PublicKey(t *testing.T) string {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("unable to encode key: %s", err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub)))
+}
+
+func testAccUserAuthorizedKeyConfig(suffix, publicKey, comment string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_local_user" "test" {
+  username  = "tf-acc-akey-%[1]s"
+  full_name = "Terraform provider Test User"
+  email     = "tf-acc-akey-%[1]s@example.com"
+}
+
+resource "privx_user_authorized_key" "test" {
+  user_id    = privx_local_user.test.id
+  name       = "tf-acc-akey-%[1]s"
+  public_key = %[2]q
+  comment    = %[3]q
+  source     = "terraform"
+}
+
+data "privx_user_authorized_keys" "test" {
+  user_id    = privx_local_user.test.id
+  depends_on = [privx_user_authorized_key.test]
+}
+`, suffix, publicKey, comment)
+}
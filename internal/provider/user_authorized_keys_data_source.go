@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserAuthorizedKeysDataSource{}
+
+// authorizedKeysPageSize is the page size used when listing authorized keys.
+const authorizedKeysPageSize = 100
+
+func NewUserAuthorizedKeysDataSource() datasource.DataSource {
+	return &UserAuthorizedKeysDataSource{}
+}
+
+// UserAuthorizedKeysDataSource defines the data source implementation.
+type UserAuthorizedKeysDataSource struct {
+	client *rolestore.RoleStore
+}
+
+// UserAuthorizedKeysDataSourceModel describes the data source data model.
+type UserAuthorizedKeysDataSourceModel struct {
+	ID     types.String                 `tfsdk:"id"`
+	UserID types.String                 `tfsdk:"user_id"`
+	Keys   []UserAuthorizedKeyItemModel `tfsdk:"keys"`
+}
+
+// UserAuthorizedKeyItemModel is one authorized key of a user.
+type UserAuthorizedKeyItemModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Comment           types.String   `tfsdk:"comment"`
+	Source            types.String   `tfsdk:"source"`
+	PublicKey         types.String   `tfsdk:"public_key"`
+	SourceAddress     []types.String `tfsdk:"source_address"`
+	Algorithm         types.String   `tfsdk:"algorithm"`
+	FingerprintSHA256 types.String   `tfsdk:"fingerprint_sha256"`
+	Created           types.String   `tfsdk:"created"`
+}
+
+func (d *UserAuthorizedKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_authorized_keys"
+}
+
+func (d *UserAuthorizedKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "User authorized keys data source. Lists the SSH public keys registered for a user.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Data source ID (same as user_id)",
+				Computed:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user",
+				Required:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "Authorized keys of the user",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Authorized key ID",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the key",
+							Computed:            true,
+						},
+						"comment": schema.StringAttribute{
+							MarkdownDescription: "A comment describing the key",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "Source of the key",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "Public key in OpenSSH authorized_keys format",
+							Computed:            true,
+						},
+						"source_address": schema.ListAttribute{
+							MarkdownDescription: "Source addresses or CIDRs the key may be used from",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "Key algorithm",
+							Computed:            true,
+						},
+						"fingerprint_sha256": schema.StringAttribute{
+							MarkdownDescription: "SHA256 fingerprint of the key",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: "Creation time",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UserAuthorizedKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = rolestore.New(*connector)
+}
+
+func (d *UserAuthorizedKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserAuthorizedKeysDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userID := data.UserID.ValueString()
+	data.Keys = []UserAuthorizedKeyItemModel{}
+
+	for offset := 0; ; offset += authorizedKeysPageSize {
+		page, err := d.client.GetUsersAuthorizedKeys(userID, filters.Paging(offset, authorizedKeysPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list authorized keys of user %s, got error: %s", userID, err))
+			return
+		}
+
+		for _, key := range page.Items {
+			item := UserAuthorizedKeyItemModel{
+				ID:                types.StringValue(key.ID),
+				Name:              types.StringValue(key.Name),
+				Comment:           types.StringValue(key.Comment),
+				Source:            types.StringValue(key.Source),
+				PublicKey:         types.StringValue(key.PublicKey),
+				SourceAddress:     []types.String{},
+				Algorithm:         types.StringValue(""),
+				FingerprintSHA256: types.StringValue(""),
+				Created:           types.StringValue(key.Created),
+			}
+			for _, address := range key.SourceAddress {
+				item.SourceAddress = append(item.SourceAddress, types.StringValue(address))
+			}
+			if info, err := parseSSHPublicKey(key.PublicKey); err == nil {
+				item.Algorithm = types.StringValue(info.Algorithm)
+				item.FingerprintSHA256 = types.StringValue(info.FingerprintSHA256)
+			}
+			data.Keys = append(data.Keys, item)
+		}
+
+		if len(page.Items) < authorizedKeysPageSize || offset+len(page.Items) >= page.Count {
+			break
+		}
+	}
+
+	data.ID = types.StringValue(userID)
+
+	tflog.Debug(ctx, "Storing user authorized keys into the state", map[string]interface{}{
+		"user_id": userID,
+		"count":   len(data.Keys),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
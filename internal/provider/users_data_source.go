@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/rolestore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client *rolestore.RoleStore
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	ID         types.String     `tfsdk:"id"`
+	NameRegex  types.String     `tfsdk:"name_regex"`
+	Tags       []types.String   `tfsdk:"tags"`
+	RoleID     types.String     `tfsdk:"role_id"`
+	Source     types.String     `tfsdk:"source"`
+	Query      types.String     `tfsdk:"query"`
+	MaxResults types.Int64      `tfsdk:"max_results"`
+	IDs        []types.String   `tfsdk:"ids"`
+	Users      []UsersItemModel `tfsdk:"users"`
+}
+
+// UsersItemModel is one user.
+type UsersItemModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Principal  types.String      `tfsdk:"principal"`
+	FullName   types.String      `tfsdk:"full_name"`
+	Email      types.String      `tfsdk:"email"`
+	Source     types.String      `tfsdk:"source"`
+	SourceType types.String      `tfsdk:"source_type"`
+	Tags       []types.String    `tfsdk:"tags"`
+	Roles      []RoleHandleModel `tfsdk:"roles"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Users data source. Lists users from all user directories, sorted by principal.",
+		Attributes: map[string]schema.Attribute{
+			"id":         computed("Data source ID"),
+			"name_regex": nameRegexAttribute("users"),
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Only users with all of these tags",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "Only users holding this role",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Only users from this user directory (source ID)",
+				Optional:            true,
+			},
+			"query": schema.StringAttribute{
+				MarkdownDescription: "Free-text search over the users",
+				Optional:            true,
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of users returned. All matching users are returned when unset; when more users match, a warning is reported.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching users",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching users",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":          computed("User ID"),
+						"principal":   computed("User principal (username)"),
+						"full_name":   computed("Full name"),
+						"email":       computed("Email address"),
+						"source":      computed("User directory the user comes from"),
+						"source_type": computed("Type of the user directory"),
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "User tags",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"roles": roleHandleListAttribute("Roles of the user"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating rolestore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = rolestore.New(*connector)
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := regexp.MustCompile(data.NameRegex.ValueString())
+	roleID := data.RoleID.ValueString()
+	search := rolestore.UserSearch{
+		Keywords: data.Query.ValueString(),
+		Source:   data.Source.ValueString(),
+	}
+
+	// Zero means no limit.
+	maxResults := int(data.MaxResults.ValueInt64())
+
+	var users []rolestore.User
+	truncated := false
+	for offset := 0; !truncated; offset += listPageSize {
+		page, err := d.client.SearchUsers(search, filters.Paging(offset, listPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to search users, got error: %s", err))
+			return
+		}
+
+		for _, user := range page.Items {
+			if !nameRegex.MatchString(user.Principal) || !hasAllTags(user.Tags, data.Tags) {
+				continue
+			}
+			if roleID != "" && !hasUserRole(user.Roles, roleID) {
+				continue
+			}
+			if maxResults > 0 && len(users) == maxResults {
+				truncated = true
+				break
+			}
+			users = append(users, user)
+		}
+
+		if len(page.Items) < listPageSize {
+			break
+		}
+	}
+	if truncated {
+		addTruncatedWarning(&resp.Diagnostics, "users", maxResults)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].Principal < users[j].Principal })
+
+	data.IDs = []types.String{}
+	data.Users = []UsersItemModel{}
+	for _, user := range users {
+		roles := make([]RoleHandleModel, len(user.Roles))
+		for i, role := range user.Roles {
+			roles[i] = RoleHandleModel{ID: types.StringValue(role.ID), Name: types.StringValue(role.Name)}
+		}
+		data.IDs = append(data.IDs, types.StringValue(user.ID))
+		data.Users = append(data.Users, UsersItemModel{
+			ID:         types.StringValue(user.ID),
+			Principal:  types.StringValue(user.Principal),
+			FullName:   types.StringValue(user.FullName),
+			Email:      types.StringValue(user.Email),
+			Source:     types.StringValue(user.Source),
+			SourceType: types.StringValue(user.SourceType),
+			Tags:       stringValues(user.Tags),
+			Roles:      roles,
+		})
+	}
+
+	data.ID = types.StringValue(strings.Join([]string{
+		data.NameRegex.ValueString(),
+		strings.Join(valueStrings(data.Tags), ","),
+		roleID,
+		search.Source,
+		search.Keywords,
+	}, "/"))
+
+	tflog.Debug(ctx, "Storing users into the state", map[string]interface{}{
+		"count": len(data.Users),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func hasUserRole(roles []rolestore.Role, roleID string) bool {
+	for _, role := range roles {
+		if role.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
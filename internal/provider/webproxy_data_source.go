@@ -149,7 +149,7 @@ func (d *WebProxyDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	var trustedClient *userstore.TrustedClient
 	for _, client := range searchResult.Items {
-		if client.Name == data.Name.ValueString() && client.Type == "ICAP" {
+		if client.Name == data.Name.ValueString() && client.Type == webProxyClientType {
 			trustedClient = &client
 			break
 		}
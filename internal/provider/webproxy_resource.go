@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-privx/internal/utils"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/userstore"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebProxyResource{}
+var _ resource.ResourceWithImportState = &WebProxyResource{}
+
+// webProxyClientType is the trusted client type PrivX uses for ICAP web proxies.
+const webProxyClientType = "ICAP"
+
+func NewWebProxyResource() resource.Resource {
+	return &WebProxyResource{}
+}
+
+// WebProxyResource defines the resource implementation.
+type WebProxyResource struct {
+	client *userstore.UserStore
+}
+
+// WebProxyResourceModel contains PrivX web proxy (ICAP trusted client) information.
+type WebProxyResourceModel struct {
+	ID                            types.String `tfsdk:"id"`
+	Name                          types.String `tfsdk:"name"`
+	Enabled                       types.Bool   `tfsdk:"enabled"`
+	AccessGroupID                 types.String `tfsdk:"access_group_id"`
+	RoutingPrefix                 types.String `tfsdk:"routing_prefix"`
+	WebProxyAddress               types.String `tfsdk:"web_proxy_address"`
+	WebProxyPort                  types.Int64  `tfsdk:"web_proxy_port"`
+	WebProxyExtenderRoutePatterns types.Set    `tfsdk:"web_proxy_extender_route_patterns"`
+	ExtenderAddress               types.Set    `tfsdk:"extender_address"`
+	Subnets                       types.Set    `tfsdk:"subnets"`
+	Permissions                   types.List   `tfsdk:"permissions"`
+	GroupID                       types.String `tfsdk:"group_id"`
+	Registered                    types.Bool   `tfsdk:"registered"`
+}
+
+func (r *WebProxyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webproxy"
+}
+
+func (r *WebProxyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "WebProxy resource. Manages an ICAP web proxy trusted client.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "WebProxy ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "WebProxy name",
+				Required:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the webproxy is enabled",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"access_group_id": schema.StringAttribute{
+				MarkdownDescription: "Access Group ID",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"routing_prefix": schema.StringAttribute{
+				MarkdownDescription: "Routing prefix for the webproxy",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"web_proxy_address": schema.StringAttribute{
+				MarkdownDescription: "Web proxy address",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"web_proxy_port": schema.Int64Attribute{
+				MarkdownDescription: "Web proxy port",
+				Optional:            true,
+			},
+			"web_proxy_extender_route_patterns": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Web proxy extender route patterns",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"extender_address": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Extender addresses",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subnets": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Subnets",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"permissions": schema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "WebProxy permissions",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "Group ID for the webproxy",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"registered": schema.BoolAttribute{
+				MarkdownDescription: "Whether the webproxy is registered",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *WebProxyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating userstore", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = userstore.New(*connector)
+}
+
+func (r *WebProxyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WebProxyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Loaded webproxy type data", map[string]interface{}{
+		"data": fmt.Sprintf("%+v", data),
+	})
+
+	webProxy := userstore.TrustedClient{
+		Type:    webProxyClientType,
+		Name:    data.Name.ValueString(),
+		Enabled: data.Enabled.ValueBool(),
+	}
+	resp.Diagnostics.Append(data.expandInto(ctx, &webProxy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("userstore.TrustedClient model used: %+v", webProxy))
+
+	webProxyID, err := r.client.CreateTrustedClient(&webProxy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	// Convert from the API data model to the Terraform data model
+	// and set any unknown attribute values.
+	data.ID = types.StringValue(webProxyID.ID)
+
+	webProxyRead, err := r.client.GetTrustedClient(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Resource",
+			"An unexpected error occurred while attempting to read the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, webProxyRead)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "created webproxy resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebProxyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WebProxyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webProxy, err := r.client.GetTrustedClient(data.ID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webproxy, got error: %s", err))
+		return
+	}
+
+	if webProxy.Type != webProxyClientType {
+		resp.Diagnostics.AddError(
+			"Unexpected Trusted Client Type",
+			fmt.Sprintf("Trusted client %s has type %q, expected %q", data.ID.ValueString(), webProxy.Type, webProxyClientType),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(data.flatten(ctx, webProxy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Storing webproxy type into the state", map[string]interface{}{
+		"createNewState": fmt.Sprintf("%+v", data),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebProxyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan WebProxyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read current object from API first to preserve server-managed fields
+	current, err := r.client.GetTrustedClient(plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webproxy before update, got error: %s", err))
+		return
+	}
+
+	current.Name = plan.Name.ValueString()
+	current.Enabled = plan.Enabled.ValueBool()
+	resp.Diagnostics.Append(plan.expandInto(ctx, current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateTrustedClient(plan.ID.ValueString(), current); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update webproxy, got error: %s", err))
+		return
+	}
+
+	updated, err := r.client.GetTrustedClient(plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read updated webproxy, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(plan.flatten(ctx, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WebProxyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WebProxyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTrustedClient(data.ID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete webproxy, got error: %s", err))
+		return
+	}
+}
+
+func (r *WebProxyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// expandInto copies the user-controlled fields that are known in the plan
+// into the trusted client payload.
+func (data *WebProxyResourceModel) expandInto(ctx context.Context, webProxy *userstore.TrustedClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.AccessGroupID.IsNull() && !data.AccessGroupID.IsUnknown() {
+		webProxy.AccessGroupID = data.AccessGroupID.ValueString()
+	}
+	if !data.RoutingPrefix.IsNull() && !data.RoutingPrefix.IsUnknown() {
+		webProxy.RoutingPrefix = data.RoutingPrefix.ValueString()
+	}
+	if !data.WebProxyAddress.IsNull() && !data.WebProxyAddress.IsUnknown() {
+		webProxy.WebProxyAddress = data.WebProxyAddress.ValueString()
+	}
+	if !data.WebProxyPort.IsNull() && !data.WebProxyPort.IsUnknown() {
+		webProxy.WebProxyPort = strconv.FormatInt(data.WebProxyPort.ValueInt64(), 10)
+	} else {
+		webProxy.WebProxyPort = ""
+	}
+	if !data.WebProxyExtenderRoutePatterns.IsNull() && !data.WebProxyExtenderRoutePatterns.IsUnknown() {
+		diags.Append(data.WebProxyExtenderRoutePatterns.ElementsAs(ctx, &webProxy.WebProxyExtenderRoutePatterns, false)...)
+	}
+	if !data.ExtenderAddress.IsNull() && !data.ExtenderAddress.IsUnknown() {
+		diags.Append(data.ExtenderAddress.ElementsAs(ctx, &webProxy.ExtenderAddress, false)...)
+	}
+	if !data.Subnets.IsNull() && !data.Subnets.IsUnknown() {
+		diags.Append(data.Subnets.ElementsAs(ctx, &webProxy.Subnets, false)...)
+	}
+
+	return diags
+}
+
+// flatten copies the trusted client returned by PrivX into the model.
+func (data *WebProxyResourceModel) flatten(ctx context.Context, webProxy *userstore.TrustedClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Name = types.StringValue(webProxy.Name)
+	data.Enabled = types.BoolValue(webProxy.Enabled)
+	data.AccessGroupID = types.StringValue(webProxy.AccessGroupID)
+	data.RoutingPrefix = types.StringValue(webProxy.RoutingPrefix)
+	data.WebProxyAddress = types.StringValue(webProxy.WebProxyAddress)
+	data.GroupID = types.StringValue(webProxy.GroupID)
+	data.Registered = types.BoolValue(webProxy.Registered)
+
+	if webProxy.WebProxyPort == "" {
+		data.WebProxyPort = types.Int64Null()
+	} else {
+		port, err := strconv.ParseInt(webProxy.WebProxyPort, 10, 64)
+		if err != nil {
+			diags.AddError("Invalid web_proxy_port", fmt.Sprintf("PrivX returned a non-numeric web proxy port %q: %s", webProxy.WebProxyPort, err))
+			return diags
+		}
+		data.WebProxyPort = types.Int64Value(port)
+	}
+
+	patterns, d := types.SetValueFrom(ctx, types.StringType, webProxy.WebProxyExtenderRoutePatterns)
+	diags.Append(d...)
+	data.WebProxyExtenderRoutePatterns = patterns
+
+	extenderAddress, d := types.SetValueFrom(ctx, types.StringType, webProxy.ExtenderAddress)
+	diags.Append(d...)
+	data.ExtenderAddress = extenderAddress
+
+	subnets, d := types.SetValueFrom(ctx, types.StringType, webProxy.Subnets)
+	diags.Append(d...)
+	data.Subnets = subnets
+
+	permissions, d := types.ListValueFrom(ctx, types.StringType, webProxy.Permissions)
+	diags.Append(d...)
+	data.Permissions = permissions
+
+	return diags
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWebProxyResource(t *testing.T) {
+	name := "tfwebproxy" + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum)
+	resourceName := "privx_webproxy.webproxy_test"
+
+	cfgCreate := testAccWebProxyConfig(name, "10.0.0.10", "rp1")
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfgCreate)
+
+	cfgUpdate := testAccWebProxyConfig(name, "10.0.0.11", "rp2")
+	writeAccConfig(t, fmt.Sprintf("%s_step_2.tf", t.Name()), cfgUpdate)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// 1) Create
+			{
+				Config: cfgCreate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "web_proxy_address", "10.0.0.10"),
+					resource.TestCheckResourceAttr(resourceName, "routing_prefix", "rp1"),
+					resource.TestCheckResourceAttrSet(resourceName, "registered"),
+					resource.TestCheckResourceAttrPair("data.privx_webproxy.webproxy_test", "id", resourceName, "id"),
+				),
+			},
+
+			// 2) Refresh-only: ensure Read is stable
+			{
+				RefreshState: true,
+			},
+
+			// 3) Update user-controlled fields
+			{
+				Config: cfgUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "web_proxy_address", "10.0.0.11"),
+					resource.TestCheckResourceAttr(resourceName, "routing_prefix", "rp2"),
+				),
+			},
+
+			// 4) Import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"registered", // can change
+				},
+			},
+		},
+	})
+}
+
+func testAccWebProxyConfig(name, address, rp string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_webproxy" "webproxy_test" {
+  name              = %q
+  web_proxy_address = %q
+  routing_prefix    = %q
+}
+
+data "privx_webproxy" "webproxy_test" {
+  name = privx_webproxy.webproxy_test.name
+}
+`, name, address, rp)
+}
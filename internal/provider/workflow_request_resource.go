@@ -0,0 +1,447 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-privx/internal/utils"
+	"time"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/workflow"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WorkflowRequestResource{}
+var _ resource.ResourceWithImportState = &WorkflowRequestResource{}
+var _ resource.ResourceWithValidateConfig = &WorkflowRequestResource{}
+
+const (
+	workflowRequestPending  = "PENDING"
+	workflowRequestAccepted = "ACCEPTED"
+	workflowRequestDenied   = "DENIED"
+
+	// workflowRequestPollInterval is the delay between status checks while
+	// waiting for a decision.
+	workflowRequestPollInterval = 5 * time.Second
+)
+
+func NewWorkflowRequestResource() resource.Resource {
+	return &WorkflowRequestResource{}
+}
+
+// WorkflowRequestResource defines the resource implementation.
+type WorkflowRequestResource struct {
+	client *workflow.WorkflowEngine
+}
+
+// WorkflowRequestResourceModel describes the resource data model.
+type WorkflowRequestResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	RoleID            types.String `tfsdk:"role_id"`
+	TargetUserID      types.String `tfsdk:"target_user_id"`
+	Justification     types.String `tfsdk:"justification"`
+	GrantType         types.String `tfsdk:"grant_type"`
+	GrantStart        types.String `tfsdk:"grant_start"`
+	GrantEnd          types.String `tfsdk:"grant_end"`
+	FloatingLength    types.Int64  `tfsdk:"floating_length"`
+	WaitForDecision   types.Bool   `tfsdk:"wait_for_decision"`
+	DecisionTimeout   types.String `tfsdk:"decision_timeout"`
+	RoleName          types.String `tfsdk:"role_name"`
+	Status            types.String `tfsdk:"status"`
+	TargetRoleRevoked types.Bool   `tfsdk:"target_role_revoked"`
+	Created           types.String `tfsdk:"created"`
+}
+
+func (r *WorkflowRequestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_request"
+}
+
+func (r *WorkflowRequestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow request resource. Files an access request for a role through the workflow configured for it " +
+			"and optionally waits for the approvers' decision. Destroying the resource revokes the granted role, or cancels the request while it is pending.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Request ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the requested role",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user the role is requested for. Defaults to the user of the provider credentials.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"justification": schema.StringAttribute{
+				MarkdownDescription: "Justification shown to the approvers",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grant_type": schema.StringAttribute{
+				MarkdownDescription: "Grant type, one of `PERMANENT`, `TIME_RESTRICTED` or `FLOATING`",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(roleGrantPermanent, roleGrantTimeRestricted, roleGrantFloating),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grant_start": schema.StringAttribute{
+				MarkdownDescription: "Start of the grant (RFC3339), only for `TIME_RESTRICTED` grants",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"grant_end": schema.StringAttribute{
+				MarkdownDescription: "End of the grant (RFC3339), only for `TIME_RESTRICTED` grants",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"floating_length": schema.Int64Attribute{
+				MarkdownDescription: "Length of the floating grant in hours, required for `FLOATING` grants",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_decision": schema.BoolAttribute{
+				MarkdownDescription: "Wait until the request is approved or denied. A denied request or a timeout fails the apply.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"decision_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for a decision, as a Go duration such as `30m`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the requested role",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the request, for example `PENDING`, `ACCEPTED` or `DENIED`",
+				Computed:            true,
+			},
+			"target_role_revoked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the granted role has been revoked",
+				Computed:            true,
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: "Time the request was filed",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *WorkflowRequestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data WorkflowRequestResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateRoleGrant(data.GrantType, data.GrantStart, data.GrantEnd, data.FloatingLength, path.Root)...)
+
+	if !data.DecisionTimeout.IsNull() && !data.DecisionTimeout.IsUnknown() {
+		if _, err := time.ParseDuration(data.DecisionTimeout.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("decision_timeout"), "Invalid Duration",
+				fmt.Sprintf("decision_timeout must be a duration such as \"30m\": %s", err))
+		}
+	}
+}
+
+func (r *WorkflowRequestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating workflow engine", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	r.client = workflow.New(*connector)
+}
+
+func (r *WorkflowRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WorkflowRequestResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := &workflow.AccessRequest{
+		RequestedRole:        &workflow.WorkflowRole{ID: data.RoleID.ValueString()},
+		RequestJustification: data.Justification.ValueString(),
+		GrantType:            data.GrantType.ValueString(),
+		GrantStart:           data.GrantStart.ValueString(),
+		GrantEnd:             data.GrantEnd.ValueString(),
+		FloatingLength:       data.FloatingLength.ValueInt64(),
+	}
+	if !data.TargetUserID.IsNull() && !data.TargetUserID.IsUnknown() {
+		request.TargetUser = &workflow.WorkflowUser{ID: data.TargetUserID.ValueString()}
+	}
+
+	tflog.Debug(ctx, "Filing workflow request", map[string]interface{}{
+		"role_id":    data.RoleID.ValueString(),
+		"grant_type": request.GrantType,
+	})
+
+	identifier, err := r.client.CreateRequest(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Resource",
+			"An unexpected error occurred while attempting to create the resource.\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(identifier.ID)
+
+	remote, err := r.client.GetRequest(identifier.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow request after create, got error: %s", err))
+		return
+	}
+	flattenWorkflowRequest(remote, &data)
+
+	// Save the request before waiting so that a failed wait taints it and a
+	// later destroy cancels or revokes it.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.WaitForDecision.ValueBool() {
+		return
+	}
+
+	timeout, _ := time.ParseDuration(data.DecisionTimeout.ValueString())
+	remote, err = waitForWorkflowDecision(ctx, r.client, identifier.ID, timeout)
+	if remote != nil {
+		flattenWorkflowRequest(remote, &data)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Workflow Request Not Approved", fmt.Sprintf("Request %s: %s", identifier.ID, err))
+		return
+	}
+	if data.Status.ValueString() == workflowRequestDenied {
+		resp.Diagnostics.AddError("Workflow Request Denied", fmt.Sprintf("Request %s for role %s was denied", identifier.ID, data.RoleName.ValueString()))
+	}
+}
+
+func (r *WorkflowRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WorkflowRequestResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.client.GetRequest(data.ID.ValueString())
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow request, got error: %s", err))
+		return
+	}
+
+	flattenWorkflowRequest(remote, &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only changes the wait settings; everything else forces replacement.
+func (r *WorkflowRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state WorkflowRequestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.WaitForDecision = plan.WaitForDecision
+	state.DecisionTimeout = plan.DecisionTimeout
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *WorkflowRequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WorkflowRequestResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestID := data.ID.ValueString()
+	remote, err := r.client.GetRequest(requestID)
+	if err != nil {
+		if utils.IsPrivxNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow request, got error: %s", err))
+		return
+	}
+
+	switch {
+	case remote.Status == workflowRequestAccepted && !remote.TargetRoleRevoked:
+		tflog.Info(ctx, "Revoking role granted by workflow request", map[string]interface{}{"id": requestID})
+		if err := r.client.RevokeTargetRole(requestID); err != nil && !utils.IsPrivxNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke role granted by workflow request, got error: %s", err))
+		}
+	case remote.Status == workflowRequestPending:
+		tflog.Info(ctx, "Cancelling pending workflow request", map[string]interface{}{"id": requestID})
+		if err := r.client.DeleteRequest(requestID); err != nil && !utils.IsPrivxNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to cancel workflow request, got error: %s", err))
+		}
+	}
+}
+
+func (r *WorkflowRequestResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_decision"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("decision_timeout"), "30m")...)
+}
+
+// waitForWorkflowDecision polls a request until it is no longer pending.
+func waitForWorkflowDecision(ctx context.Context, client *workflow.WorkflowEngine, requestID string, timeout time.Duration) (*workflow.AccessRequest, error) {
+	startTime := time.Now()
+	for {
+		request, err := client.GetRequest(requestID)
+		if err != nil {
+			return nil, err
+		}
+
+		if request.Status != workflowRequestPending {
+			return request, nil
+		}
+		if time.Since(startTime) > timeout {
+			return request, fmt.Errorf("no decision within %s", timeout)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for workflow request decision (%s timeout)", timeout))
+		select {
+		case <-ctx.Done():
+			return request, ctx.Err()
+		case <-time.After(workflowRequestPollInterval):
+		}
+	}
+}
+
+func flattenWorkflowRequest(remote *workflow.AccessRequest, data *WorkflowRequestResourceModel) {
+	data.ID = types.StringValue(remote.ID)
+	if remote.RequestedRole != nil {
+		data.RoleID = types.StringValue(remote.RequestedRole.ID)
+		data.RoleName = types.StringValue(remote.RequestedRole.Name)
+	}
+	// A request without a target user is for the requester, the user of the
+	// provider credentials.
+	switch {
+	case remote.TargetUser != nil && remote.TargetUser.ID != "":
+		data.TargetUserID = types.StringValue(remote.TargetUser.ID)
+	case !data.TargetUserID.IsUnknown():
+		// Keep the configured or stored user.
+	case remote.Requester != nil && remote.Requester.ID != "":
+		data.TargetUserID = types.StringValue(remote.Requester.ID)
+	default:
+		data.TargetUserID = types.StringNull()
+	}
+	if remote.RequestJustification != "" || !data.Justification.IsNull() {
+		data.Justification = types.StringValue(remote.RequestJustification)
+	}
+	if remote.GrantType != "" {
+		data.GrantType = types.StringValue(remote.GrantType)
+	}
+
+	// The server may normalise timestamps; keep the configured spelling of
+	// the same instant.
+	data.GrantStart = workflowRequestTime(remote.GrantStart, data.GrantStart)
+	data.GrantEnd = workflowRequestTime(remote.GrantEnd, data.GrantEnd)
+	if remote.FloatingLength != 0 {
+		data.FloatingLength = types.Int64Value(remote.FloatingLength)
+	}
+
+	data.Status = types.StringValue(remote.Status)
+	data.TargetRoleRevoked = types.BoolValue(remote.TargetRoleRevoked)
+	data.Created = types.StringValue(remote.Created)
+}
+
+func workflowRequestTime(value string, prior types.String) types.String {
+	if value == "" {
+		if prior.IsNull() {
+			return types.StringNull()
+		}
+		return types.StringValue("")
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		was, errWas := time.Parse(time.RFC3339, prior.ValueString())
+		now, errNow := time.Parse(time.RFC3339, value)
+		if errWas == nil && errNow == nil && was.Equal(now) {
+			return prior
+		}
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWorkflowRequestResource(t *testing.T) {
+	suffix := acctest.RandStringFromCharSet(8, "abcdefghijklmnopqrstuvwxyz0123456789")
+	resourceName := "privx_workflow_request.test"
+
+	cfg := testAccWorkflowRequestConfig(suffix)
+	writeAccConfig(t, fmt.Sprintf("%s_step_1.tf", t.Name()), cfg)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// CREATE without waiting: nobody approves in the test run, so
+			// the request stays pending and destroy cancels it.
+			{
+				Config: cfg,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "role_id", "privx_role.target", "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "PENDING"),
+					resource.TestCheckResourceAttr(resourceName, "target_role_revoked", "false"),
+				),
+			},
+
+			// IMPORT
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_decision", "decision_timeout"},
+			},
+		},
+	})
+}
+
+func testAccWorkflowRequestConfig(suffix string) string {
+	return fmt.Sprintf(`
+terraform {
+  required_providers {
+    privx = {
+      source = "hashicorp/privx"
+    }
+  }
+}
+
+provider "privx" {}
+
+resource "privx_access_group" "acc" {
+  name    = "tf-acc-wfr-%[1]s"
+  comment = "temp group for workflow request test"
+}
+
+resource "privx_role" "target" {
+  name            = "tf-acc-wfr-%[1]s"
+  access_group_id = privx_access_group.acc.id
+
+  permissions  = ["users-view"]
+  permit_agent = false
+
+  source_rules = jsonencode({
+    type  = "GROUP"
+    match = "ANY"
+    rules = []
+  })
+}
+
+data "privx_role" "admin" {
+  name = "privx-admin"
+}
+
+resource "privx_workflow" "test" {
+  name                = "tf-acc-wfr-%[1]s"
+  grant_types         = ["TIME_RESTRICTED"]
+  max_active_requests = 1
+
+  target_roles = [{
+    id   = privx_role.target.id
+    name = privx_role.target.name
+  }]
+
+  requester_roles = [{
+    id   = data.privx_role.admin.id
+    name = data.privx_role.admin.name
+  }]
+
+  action = "GRANT"
+
+  steps = [
+    {
+      name  = "Approval"
+      match = "ANY"
+      approvers = [
+        {
+          role = {
+            id   = data.privx_role.admin.id
+            name = data.privx_role.admin.name
+          }
+        }
+      ]
+    }
+  ]
+}
+
+resource "privx_workflow_request" "test" {
+  role_id       = privx_role.target.id
+  justification = "terraform acceptance test"
+
+  grant_type  = "TIME_RESTRICTED"
+  grant_start = "2030-01-01T00:00:00Z"
+  grant_end   = "2030-01-01T01:00:00Z"
+
+  wait_for_decision = false
+
+  depends_on = [privx_workflow.test]
+}
+`, suffix)
+}
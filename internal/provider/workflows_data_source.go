@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SSHcom/privx-sdk-go/v2/api/filters"
+	"github.com/SSHcom/privx-sdk-go/v2/api/workflow"
+	"github.com/SSHcom/privx-sdk-go/v2/restapi"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowsDataSource{}
+
+func NewWorkflowsDataSource() datasource.DataSource {
+	return &WorkflowsDataSource{}
+}
+
+// WorkflowsDataSource defines the data source implementation.
+type WorkflowsDataSource struct {
+	client *workflow.WorkflowEngine
+}
+
+// WorkflowsDataSourceModel describes the data source data model.
+type WorkflowsDataSourceModel struct {
+	ID           types.String         `tfsdk:"id"`
+	NameRegex    types.String         `tfsdk:"name_regex"`
+	TargetRoleID types.String         `tfsdk:"target_role_id"`
+	Author       types.String         `tfsdk:"author"`
+	IDs          []types.String       `tfsdk:"ids"`
+	Workflows    []WorkflowsItemModel `tfsdk:"workflows"`
+}
+
+// WorkflowsItemModel is one workflow.
+type WorkflowsItemModel struct {
+	ID                    types.String      `tfsdk:"id"`
+	Name                  types.String      `tfsdk:"name"`
+	Comment               types.String      `tfsdk:"comment"`
+	Action                types.String      `tfsdk:"action"`
+	GrantTypes            []types.String    `tfsdk:"grant_types"`
+	TargetRoles           []RoleHandleModel `tfsdk:"target_roles"`
+	RequesterRoles        []RoleHandleModel `tfsdk:"requester_roles"`
+	RequiresJustification types.Bool        `tfsdk:"requires_justification"`
+	MaxActiveRequests     types.Int64       `tfsdk:"max_active_requests"`
+	Author                types.String      `tfsdk:"author"`
+	Created               types.String      `tfsdk:"created"`
+}
+
+func (d *WorkflowsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflows"
+}
+
+func (d *WorkflowsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflows data source. Lists workflows sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"id":         computed("Data source ID"),
+			"name_regex": nameRegexAttribute("workflows"),
+			"target_role_id": schema.StringAttribute{
+				MarkdownDescription: "Only workflows granting this role",
+				Optional:            true,
+			},
+			"author": schema.StringAttribute{
+				MarkdownDescription: "Only workflows created by this user ID",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching workflows",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"workflows": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching workflows",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":      computed("Workflow ID"),
+						"name":    computed("Workflow name"),
+						"comment": computed("Workflow comment"),
+						"action":  computed("Workflow action"),
+						"grant_types": schema.ListAttribute{
+							MarkdownDescription: "Grant types requesters may ask for",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"target_roles":    roleHandleListAttribute("Roles granted by the workflow"),
+						"requester_roles": roleHandleListAttribute("Roles allowed to request"),
+						"requires_justification": schema.BoolAttribute{
+							MarkdownDescription: "Whether requests need a justification",
+							Computed:            true,
+						},
+						"max_active_requests": schema.Int64Attribute{
+							MarkdownDescription: "Maximum number of active requests per user",
+							Computed:            true,
+						},
+						"author":  computed("ID of the user who created the workflow"),
+						"created": computed("Creation time"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkflowsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	connector, ok := req.ProviderData.(*restapi.Connector)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *restapi.Connector, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	tflog.Debug(ctx, "Creating workflow engine", map[string]interface{}{
+		"connector : ": fmt.Sprintf("%+v", *connector),
+	})
+
+	d.client = workflow.New(*connector)
+}
+
+func (d *WorkflowsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkflowsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := regexp.MustCompile(data.NameRegex.ValueString())
+	targetRoleID := data.TargetRoleID.ValueString()
+	author := data.Author.ValueString()
+
+	var workflows []workflow.Workflow
+	for offset := 0; ; offset += listPageSize {
+		page, err := d.client.GetWorkflows(filters.Paging(offset, listPageSize))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workflows, got error: %s", err))
+			return
+		}
+
+		for _, wf := range page.Items {
+			if !nameRegex.MatchString(wf.Name) || (author != "" && wf.Author != author) {
+				continue
+			}
+			if targetRoleID != "" && !hasWorkflowRole(wf.TargetRoles, targetRoleID) {
+				continue
+			}
+			workflows = append(workflows, wf)
+		}
+
+		if len(page.Items) < listPageSize {
+			break
+		}
+	}
+
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Name < workflows[j].Name })
+
+	data.IDs = []types.String{}
+	data.Workflows = []WorkflowsItemModel{}
+	for _, wf := range workflows {
+		data.IDs = append(data.IDs, types.StringValue(wf.ID))
+		data.Workflows = append(data.Workflows, WorkflowsItemModel{
+			ID:                    types.StringValue(wf.ID),
+			Name:                  types.StringValue(wf.Name),
+			Comment:               types.StringValue(wf.Comment),
+			Action:                types.StringValue(wf.Action),
+			GrantTypes:            stringValues(wf.GrantTypes),
+			TargetRoles:           workflowRoleModels(wf.TargetRoles),
+			RequesterRoles:        workflowRoleModels(wf.RequestorRoles),
+			RequiresJustification: types.BoolValue(wf.RequiresJustification),
+			MaxActiveRequests:     types.Int64Value(wf.MaxActiveRequests),
+			Author:                types.StringValue(wf.Author),
+			Created:               types.StringValue(wf.Created),
+		})
+	}
+
+	data.ID = types.StringValue(strings.Join([]string{data.NameRegex.ValueString(), targetRoleID, author}, "/"))
+
+	tflog.Debug(ctx, "Storing workflows into the state", map[string]interface{}{
+		"count": len(data.Workflows),
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func workflowRoleModels(roles []workflow.WorkflowRole) []RoleHandleModel {
+	result := make([]RoleHandleModel, len(roles))
+	for i, role := range roles {
+		result[i] = RoleHandleModel{ID: types.StringValue(role.ID), Name: types.StringValue(role.Name)}
+	}
+	return result
+}
+
+func hasWorkflowRole(roles []workflow.WorkflowRole, roleID string) bool {
+	for _, role := range roles {
+		if role.ID == roleID {
+			return true
+		}
+	}
+	return false
+}